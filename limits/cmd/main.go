@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/underbek/examples-go/app"
+	goKitConfig "github.com/underbek/examples-go/config"
+	"github.com/underbek/examples-go/limits/config"
+	"github.com/underbek/examples-go/limits/fx"
+	"github.com/underbek/examples-go/limits/migrations"
+	"github.com/underbek/examples-go/limits/server"
+	"github.com/underbek/examples-go/limits/service"
+	"github.com/underbek/examples-go/limits/service/scheduler"
+	"github.com/underbek/examples-go/limits/storage"
+	timeProvider "github.com/underbek/examples-go/limits/time_provider"
+	"github.com/underbek/examples-go/migrate"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/transport/grpcserver"
+)
+
+func main() {
+	cfg, err := goKitConfig.New[config.Config]()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	a, err := app.NewApp(cfg.App, cfg.Jaeger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	err = migrate.Run(cfg.Storage.DSN, migrate.WithFs(migrations.Migrations), migrate.WithLogger(a.Logger))
+	if err != nil {
+		a.Logger.WithError(err).Fatal("migrations failed")
+	}
+
+	db, err := goKitPgx.New(
+		ctx,
+		cfg.Storage,
+		goKitPgx.WithLogger(a.Logger),
+		goKitPgx.WithMetrics(),
+		goKitPgx.WithTrace(),
+	)
+	if err != nil {
+		a.Logger.WithError(err).Fatal("storage connection failed")
+	}
+
+	a.AddDefers(db.Close)
+
+	var opts []service.Option
+
+	switch {
+	case cfg.FX.URL != "":
+		opts = append(opts, service.WithRateProvider(fx.NewHTTP(cfg.FX.URL)))
+	case len(cfg.FX.Rates) != 0:
+		rates, err := fx.ParseRates(cfg.FX.Rates)
+		if err != nil {
+			a.Logger.WithError(err).Fatal("parse fx rates failed")
+		}
+
+		opts = append(opts, service.WithRateProvider(fx.NewStatic(rates)))
+	}
+
+	limits := service.New(
+		a.Logger,
+		cfg.StorageTransaction,
+		db,
+		func(ext goKitPgx.ExtContext) service.Storage {
+			return storage.New(a.Logger, ext, cfg.MaxLimit)
+		},
+		timeProvider.RealTime{},
+		opts...,
+	)
+
+	grpcServer := grpcserver.New(a.Logger, cfg.GRPCServer, func(ctx context.Context) bool {
+		return db.Ping(ctx) == nil
+	})
+
+	server.Register(grpcServer, limits)
+
+	cleaner := scheduler.New(a.Logger, storage.New(a.Logger, db, cfg.MaxLimit), cfg.Scheduler)
+
+	a.AddRunners(grpcServer.Run, cleaner.Run)
+
+	if err = a.Run(ctx); err != nil {
+		a.Logger.WithError(err).Error("application stopped with error")
+	}
+}
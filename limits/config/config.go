@@ -18,6 +18,14 @@ type Config struct {
 	LimitServiceConfig
 	Scheduler          Scheduler          `envPrefix:"SCHEDULER_"`
 	StorageTransaction StorageTransaction `envPrefix:"POSTGRES_TRANSACTION_"`
+	FX                 FX                 `envPrefix:"FX_"`
+}
+
+// FX configures the rates of the any currency limits: the rates service by URL or the static table,
+// e.g. FX_RATES="EUR/USD:1.08,GBP/USD:1.27"
+type FX struct {
+	URL   string            `env:"URL"`
+	Rates map[string]string `env:"RATES"`
 }
 
 type StorageTransaction struct {
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// OperationCheck is the result of the operation checked against the matched limits without saving it
+type OperationCheck struct {
+	Exceeded []ExceededLimit   `json:"exceeded"`
+	Counters []CounterHeadroom `json:"counters"`
+}
+
+type ExceededLimit struct {
+	LimitID    uint64          `json:"limit_id"`
+	LimitType  LimitType       `json:"limit_type"`
+	Period     *PeriodType     `json:"period,omitempty"`
+	LimitValue decimal.Decimal `json:"limit_value"`
+	// NewValue is the operation amount in the limit currency for the static limits and the counter value with the operation for the total ones
+	NewValue decimal.Decimal `json:"new_value"`
+}
+
+type CounterHeadroom struct {
+	LimitID uint64 `json:"limit_id"`
+	// CounterID is nil until the first operation of the period
+	CounterID  *uint64         `json:"counter_id,omitempty"`
+	LimitType  LimitType       `json:"limit_type"`
+	Period     PeriodType      `json:"period"`
+	StartTime  time.Time       `json:"start_time"`
+	EndTime    time.Time       `json:"end_time"`
+	LimitValue decimal.Decimal `json:"limit_value"`
+	Value      decimal.Decimal `json:"value"`
+	Remaining  decimal.Decimal `json:"remaining"`
+}
@@ -22,3 +22,17 @@ type ExceededCounters struct {
 	LimitValue decimal.Decimal `json:"limit_value" db:"limit_value"`
 	NewValue   decimal.Decimal `json:"new_value" db:"new_value"`
 }
+
+type CounterValue struct {
+	LimitID   uint64          `json:"limit_id" db:"limit_id"`
+	CounterID *uint64         `json:"counter_id,omitempty" db:"counter_id"`
+	Value     decimal.Decimal `json:"value" db:"value"`
+}
+
+type CounterUsage struct {
+	LimitID   uint64          `json:"limit_id" db:"limit_id"`
+	CounterID *uint64         `json:"counter_id,omitempty" db:"counter_id"`
+	Value     decimal.Decimal `json:"value" db:"value"`
+	Pending   decimal.Decimal `json:"pending" db:"pending"`
+	Committed decimal.Decimal `json:"committed" db:"committed"`
+}
@@ -16,24 +16,33 @@ TOTAL_COUNT
 */
 type LimitType int
 
+// PeriodType is the period of the total limits.
+// HOURS splits the day into the buckets and ROLLING is the window before the operation,
+// both take the length in hours from Limit.PeriodLength.
 /*
 ENUM(
 CALENDAR_DAY
 CALENDAR_WEEK
 CALENDAR_MONTH
+CALENDAR_QUARTER
+CALENDAR_YEAR
+HOURS
+ROLLING
 )
 */
 type PeriodType int
 
 type Limit struct {
-	ID        uint64          `json:"id" db:"id" updateApi:"limit_id"`
-	Hash      string          `json:"hash" db:"hash"`
-	LimitType LimitType       `json:"limit_type" db:"limit_type" updateApi:"limit_type"`
-	Currency  string          `json:"currency" db:"currency" updateApi:"currency"`
-	Value     decimal.Decimal `json:"value" db:"value" updateApi:"value"`
-	Entities  Attributes      `json:"entities" db:"meta" updateApi:"entities"`
-	Period    *PeriodType     `json:"period,omitempty" db:"period" updateApi:"period"`
-	Timezone  *string         `json:"timezone,omitempty" db:"timezone" updateApi:"timezone"`
-	CreatedAt time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+	ID           uint64          `json:"id" db:"id" updateApi:"limit_id"`
+	Hash         string          `json:"hash" db:"hash"`
+	LimitType    LimitType       `json:"limit_type" db:"limit_type" updateApi:"limit_type"`
+	Currency     string          `json:"currency" db:"currency" updateApi:"currency"`
+	AnyCurrency  bool            `json:"any_currency" db:"any_currency" updateApi:"any_currency"`
+	Value        decimal.Decimal `json:"value" db:"value" updateApi:"value"`
+	Entities     Attributes      `json:"entities" db:"meta" updateApi:"entities"`
+	Period       *PeriodType     `json:"period,omitempty" db:"period" updateApi:"period"`
+	PeriodLength *uint32         `json:"period_length,omitempty" db:"period_length" updateApi:"period_length"`
+	Timezone     *string         `json:"timezone,omitempty" db:"timezone" updateApi:"timezone"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
 }
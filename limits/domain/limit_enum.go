@@ -160,16 +160,28 @@ const (
 	PeriodTypeCALENDARWEEK
 	// PeriodTypeCALENDARMONTH is a PeriodType of type CALENDAR_MONTH.
 	PeriodTypeCALENDARMONTH
+	// PeriodTypeCALENDARQUARTER is a PeriodType of type CALENDAR_QUARTER.
+	PeriodTypeCALENDARQUARTER
+	// PeriodTypeCALENDARYEAR is a PeriodType of type CALENDAR_YEAR.
+	PeriodTypeCALENDARYEAR
+	// PeriodTypeHOURS is a PeriodType of type HOURS.
+	PeriodTypeHOURS
+	// PeriodTypeROLLING is a PeriodType of type ROLLING.
+	PeriodTypeROLLING
 )
 
 var ErrInvalidPeriodType = errors.New("not a valid PeriodType")
 
-const _PeriodTypeName = "calendar_daycalendar_weekcalendar_month"
+const _PeriodTypeName = "calendar_daycalendar_weekcalendar_monthcalendar_quartercalendar_yearhoursrolling"
 
 var _PeriodTypeMap = map[PeriodType]string{
-	PeriodTypeCALENDARDAY:   _PeriodTypeName[0:12],
-	PeriodTypeCALENDARWEEK:  _PeriodTypeName[12:25],
-	PeriodTypeCALENDARMONTH: _PeriodTypeName[25:39],
+	PeriodTypeCALENDARDAY:     _PeriodTypeName[0:12],
+	PeriodTypeCALENDARWEEK:    _PeriodTypeName[12:25],
+	PeriodTypeCALENDARMONTH:   _PeriodTypeName[25:39],
+	PeriodTypeCALENDARQUARTER: _PeriodTypeName[39:55],
+	PeriodTypeCALENDARYEAR:    _PeriodTypeName[55:68],
+	PeriodTypeHOURS:           _PeriodTypeName[68:73],
+	PeriodTypeROLLING:         _PeriodTypeName[73:80],
 }
 
 // String implements the Stringer interface.
@@ -191,6 +203,10 @@ var _PeriodTypeValue = map[string]PeriodType{
 	_PeriodTypeName[0:12]:  PeriodTypeCALENDARDAY,
 	_PeriodTypeName[12:25]: PeriodTypeCALENDARWEEK,
 	_PeriodTypeName[25:39]: PeriodTypeCALENDARMONTH,
+	_PeriodTypeName[39:55]: PeriodTypeCALENDARQUARTER,
+	_PeriodTypeName[55:68]: PeriodTypeCALENDARYEAR,
+	_PeriodTypeName[68:73]: PeriodTypeHOURS,
+	_PeriodTypeName[73:80]: PeriodTypeROLLING,
 }
 
 // ParsePeriodType attempts to convert a string to a PeriodType.
@@ -22,6 +22,7 @@ type Operation struct {
 	Currency  string          `json:"currency" db:"currency"`
 	Value     decimal.Decimal `json:"value" db:"value"`
 	Status    OperationStatus `json:"status" db:"status"`
+	Rates     Rates           `json:"rates,omitempty" db:"rates"`
 	CreatedAt time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rates are the rates from the operation currency to the currencies of the any currency limits
+type Rates map[string]decimal.Decimal
+
+// Scan implements the Scanner interface.
+func (r *Rates) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch data := value.(type) {
+	case []byte:
+		return json.Unmarshal(data, r)
+	case string:
+		return json.Unmarshal([]byte(data), r)
+	default:
+		return fmt.Errorf("invalid type")
+	}
+}
+
+// Value implements the driver Valuer interface.
+func (r Rates) Value() (driver.Value, error) {
+	if r == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(map[string]decimal.Decimal(r))
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+type UsageFilter struct {
+	Entities   Attributes  `json:"entities"`
+	Currency   *string     `json:"currency,omitempty"`
+	LimitTypes []LimitType `json:"limit_types,omitempty"`
+	Period     *PeriodType `json:"period,omitempty"`
+}
+
+// LimitUsage is the usage of the total limit within the current period
+type LimitUsage struct {
+	CounterHeadroom
+	Currency string `json:"currency"`
+	// Pending and Committed are the totals of the operations counted in the period by their status
+	Pending   decimal.Decimal `json:"pending"`
+	Committed decimal.Decimal `json:"committed"`
+}
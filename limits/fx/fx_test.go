@@ -0,0 +1,90 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+func TestStatic_Rate(t *testing.T) {
+	rates, err := ParseRates(map[string]string{"eur/usd": "1.25"})
+	require.NoError(t, err)
+
+	provider := NewStatic(rates)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    decimal.Decimal
+		wantErr bool
+	}{
+		{name: "same currency", from: "USD", to: "USD", want: decimal.NewFromInt(1)},
+		{name: "direct pair", from: "EUR", to: "USD", want: decimal.RequireFromString("1.25")},
+		{name: "reverse pair", from: "USD", to: "EUR", want: decimal.RequireFromString("0.8")},
+		{name: "unknown pair", from: "GBP", to: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := provider.Rate(context.Background(), tt.from, tt.to)
+			if tt.wantErr {
+				assert.Equal(t, ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(rate), rate.String())
+		})
+	}
+}
+
+func TestParseRates_Invalid(t *testing.T) {
+	for _, rates := range []map[string]string{
+		{"EURUSD": "1.1"},
+		{"EUR/USD": "one"},
+		{"EUR/USD": "0"},
+	} {
+		_, err := ParseRates(rates)
+		assert.Error(t, err)
+	}
+}
+
+func TestHTTP_Rate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("from") + "/" + r.URL.Query().Get("to") {
+		case "EUR/USD":
+			_, _ = fmt.Fprint(w, `{"rate": "1.08"}`)
+		case "GBP/USD":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewHTTP(server.URL, WithClient(server.Client()))
+	ctx := context.Background()
+
+	rate, err := provider.Rate(ctx, "EUR", "USD")
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("1.08").Equal(rate))
+
+	rate, err = provider.Rate(ctx, "USD", "USD")
+	require.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(1).Equal(rate))
+
+	_, err = provider.Rate(ctx, "GBP", "USD")
+	assert.Equal(t, ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+
+	_, err = provider.Rate(ctx, "JPY", "USD")
+	assert.Equal(t, ctxerrors.TypeExternal, ctxerrors.ErrorType(err))
+}
@@ -0,0 +1,90 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/shopspring/decimal"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+const defaultTimeout = time.Second * 5
+
+// HTTP requests the rates from the rates service:
+// GET <url>?from=EUR&to=USD responds with {"rate": "1.08"}
+type HTTP struct {
+	url    string
+	client *http.Client
+}
+
+type HTTPOption func(*HTTP)
+
+// WithClient Defines the http client instead of the default one with 5s timeout
+func WithClient(client *http.Client) HTTPOption {
+	return func(h *HTTP) {
+		h.client = client
+	}
+}
+
+func NewHTTP(url string, opts ...HTTPOption) *HTTP {
+	h := &HTTP{
+		url:    url,
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+type rateResponse struct {
+	Rate decimal.Decimal `json:"rate"`
+}
+
+func (h *HTTP) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	query := url.Values{}
+	query.Set("from", from)
+	query.Set("to", to)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return decimal.Decimal{}, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "create rate request failed")
+	}
+
+	response, err := h.client.Do(request)
+	if err != nil {
+		return decimal.Decimal{}, ctxerrors.Wrap(err, ctxerrors.TypeExternal, "rate request failed")
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return decimal.Decimal{}, ctxerrors.New(ctxerrors.TypeNotFound, fmt.Sprintf("rate of %s is not found", pair(from, to)))
+	case response.StatusCode != http.StatusOK:
+		return decimal.Decimal{}, ctxerrors.New(
+			ctxerrors.TypeExternal,
+			fmt.Sprintf("rate request of %s failed with status %d", pair(from, to), response.StatusCode),
+		)
+	}
+
+	var result rateResponse
+	if err = json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return decimal.Decimal{}, ctxerrors.Wrap(err, ctxerrors.TypeExternal, "decode rate response failed")
+	}
+
+	if !result.Rate.IsPositive() {
+		return decimal.Decimal{}, ctxerrors.New(ctxerrors.TypeExternal, fmt.Sprintf("rate of %s is not positive", pair(from, to)))
+	}
+
+	return result.Rate, nil
+}
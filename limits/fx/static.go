@@ -0,0 +1,65 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+// Static provides the rates of the fixed table, the pair is written as "EUR/USD"
+type Static struct {
+	rates map[string]decimal.Decimal
+}
+
+func NewStatic(rates map[string]decimal.Decimal) *Static {
+	return &Static{
+		rates: rates,
+	}
+}
+
+// ParseRates parses the table of the rates written as strings, e.g. from the config
+func ParseRates(rates map[string]string) (map[string]decimal.Decimal, error) {
+	result := make(map[string]decimal.Decimal, len(rates))
+	for pair, value := range rates {
+		if len(strings.Split(pair, "/")) != 2 {
+			return nil, ctxerrors.New(ctxerrors.TypeInvalidRequest, fmt.Sprintf("invalid currency pair %q", pair))
+		}
+
+		rate, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, fmt.Sprintf("invalid rate %q of %s", value, pair))
+		}
+
+		if !rate.IsPositive() {
+			return nil, ctxerrors.New(ctxerrors.TypeInvalidRequest, fmt.Sprintf("rate of %s is not positive", pair))
+		}
+
+		result[strings.ToUpper(pair)] = rate
+	}
+
+	return result, nil
+}
+
+// Rate returns the rate of the pair or the inverted rate of the reverse pair
+func (s *Static) Rate(_ context.Context, from, to string) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	if rate, ok := s.rates[pair(from, to)]; ok {
+		return rate, nil
+	}
+
+	if rate, ok := s.rates[pair(to, from)]; ok {
+		return decimal.NewFromInt(1).Div(rate), nil
+	}
+
+	return decimal.Decimal{}, ctxerrors.New(ctxerrors.TypeNotFound, fmt.Sprintf("rate of %s is not found", pair(from, to)))
+}
+
+func pair(from, to string) string {
+	return strings.ToUpper(from) + "/" + strings.ToUpper(to)
+}
@@ -0,0 +1,12 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE period_type ADD VALUE IF NOT EXISTS 'calendar_quarter';
+ALTER TYPE period_type ADD VALUE IF NOT EXISTS 'calendar_year';
+ALTER TYPE period_type ADD VALUE IF NOT EXISTS 'hours';
+ALTER TYPE period_type ADD VALUE IF NOT EXISTS 'rolling';
+
+ALTER TABLE limits ADD COLUMN IF NOT EXISTS period_length integer;
+
+-- +goose Down
+-- the new values of period_type are kept, postgres can't drop the values of an enum
+ALTER TABLE limits DROP COLUMN IF EXISTS period_length;
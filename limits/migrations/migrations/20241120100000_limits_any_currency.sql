@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE limits ADD COLUMN IF NOT EXISTS any_currency boolean default false not null;
+ALTER TABLE operations ADD COLUMN IF NOT EXISTS rates jsonb default '{}' not null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE operations DROP COLUMN IF EXISTS rates;
+ALTER TABLE limits DROP COLUMN IF EXISTS any_currency;
+-- +goose StatementEnd
@@ -0,0 +1,417 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: limits.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LimitsService_CreateLimit_FullMethodName        = "/limits.LimitsService/CreateLimit"
+	LimitsService_UpdateLimit_FullMethodName        = "/limits.LimitsService/UpdateLimit"
+	LimitsService_DeleteLimits_FullMethodName       = "/limits.LimitsService/DeleteLimits"
+	LimitsService_GetLimits_FullMethodName          = "/limits.LimitsService/GetLimits"
+	LimitsService_SendOperation_FullMethodName      = "/limits.LimitsService/SendOperation"
+	LimitsService_AppendOperation_FullMethodName    = "/limits.LimitsService/AppendOperation"
+	LimitsService_FinalizeOperations_FullMethodName = "/limits.LimitsService/FinalizeOperations"
+	LimitsService_CheckOperation_FullMethodName     = "/limits.LimitsService/CheckOperation"
+	LimitsService_GetUsage_FullMethodName           = "/limits.LimitsService/GetUsage"
+)
+
+// LimitsServiceClient is the client API for LimitsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LimitsServiceClient interface {
+	CreateLimit(ctx context.Context, in *CreateLimitRequest, opts ...grpc.CallOption) (*Limit, error)
+	// UpdateLimit changes the value in place, other changes replace the limit and reset its counters
+	UpdateLimit(ctx context.Context, in *UpdateLimitRequest, opts ...grpc.CallOption) (*Limit, error)
+	DeleteLimits(ctx context.Context, in *DeleteLimitsRequest, opts ...grpc.CallOption) (*DeleteLimitsResponse, error)
+	GetLimits(ctx context.Context, in *GetLimitsRequest, opts ...grpc.CallOption) (*GetLimitsResponse, error)
+	// SendOperation checks the operation against the matched limits and holds it in a new context
+	SendOperation(ctx context.Context, in *SendOperationRequest, opts ...grpc.CallOption) (*SendOperationResponse, error)
+	// AppendOperation adds entities to the context and applies the newly matched limits
+	AppendOperation(ctx context.Context, in *AppendOperationRequest, opts ...grpc.CallOption) (*AppendOperationResponse, error)
+	// FinalizeOperations commits or rolls back the pending operations of the context
+	FinalizeOperations(ctx context.Context, in *FinalizeOperationsRequest, opts ...grpc.CallOption) (*FinalizeOperationsResponse, error)
+	// CheckOperation checks the operation against the matched limits like SendOperation, but saves nothing
+	CheckOperation(ctx context.Context, in *CheckOperationRequest, opts ...grpc.CallOption) (*CheckOperationResponse, error)
+	// GetUsage returns the usage of the total limits applied to the entities within their current periods
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+}
+
+type limitsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLimitsServiceClient(cc grpc.ClientConnInterface) LimitsServiceClient {
+	return &limitsServiceClient{cc}
+}
+
+func (c *limitsServiceClient) CreateLimit(ctx context.Context, in *CreateLimitRequest, opts ...grpc.CallOption) (*Limit, error) {
+	out := new(Limit)
+	err := c.cc.Invoke(ctx, LimitsService_CreateLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) UpdateLimit(ctx context.Context, in *UpdateLimitRequest, opts ...grpc.CallOption) (*Limit, error) {
+	out := new(Limit)
+	err := c.cc.Invoke(ctx, LimitsService_UpdateLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) DeleteLimits(ctx context.Context, in *DeleteLimitsRequest, opts ...grpc.CallOption) (*DeleteLimitsResponse, error) {
+	out := new(DeleteLimitsResponse)
+	err := c.cc.Invoke(ctx, LimitsService_DeleteLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) GetLimits(ctx context.Context, in *GetLimitsRequest, opts ...grpc.CallOption) (*GetLimitsResponse, error) {
+	out := new(GetLimitsResponse)
+	err := c.cc.Invoke(ctx, LimitsService_GetLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) SendOperation(ctx context.Context, in *SendOperationRequest, opts ...grpc.CallOption) (*SendOperationResponse, error) {
+	out := new(SendOperationResponse)
+	err := c.cc.Invoke(ctx, LimitsService_SendOperation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) AppendOperation(ctx context.Context, in *AppendOperationRequest, opts ...grpc.CallOption) (*AppendOperationResponse, error) {
+	out := new(AppendOperationResponse)
+	err := c.cc.Invoke(ctx, LimitsService_AppendOperation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) FinalizeOperations(ctx context.Context, in *FinalizeOperationsRequest, opts ...grpc.CallOption) (*FinalizeOperationsResponse, error) {
+	out := new(FinalizeOperationsResponse)
+	err := c.cc.Invoke(ctx, LimitsService_FinalizeOperations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) CheckOperation(ctx context.Context, in *CheckOperationRequest, opts ...grpc.CallOption) (*CheckOperationResponse, error) {
+	out := new(CheckOperationResponse)
+	err := c.cc.Invoke(ctx, LimitsService_CheckOperation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, LimitsService_GetUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LimitsServiceServer is the server API for LimitsService service.
+// All implementations must embed UnimplementedLimitsServiceServer
+// for forward compatibility
+type LimitsServiceServer interface {
+	CreateLimit(context.Context, *CreateLimitRequest) (*Limit, error)
+	// UpdateLimit changes the value in place, other changes replace the limit and reset its counters
+	UpdateLimit(context.Context, *UpdateLimitRequest) (*Limit, error)
+	DeleteLimits(context.Context, *DeleteLimitsRequest) (*DeleteLimitsResponse, error)
+	GetLimits(context.Context, *GetLimitsRequest) (*GetLimitsResponse, error)
+	// SendOperation checks the operation against the matched limits and holds it in a new context
+	SendOperation(context.Context, *SendOperationRequest) (*SendOperationResponse, error)
+	// AppendOperation adds entities to the context and applies the newly matched limits
+	AppendOperation(context.Context, *AppendOperationRequest) (*AppendOperationResponse, error)
+	// FinalizeOperations commits or rolls back the pending operations of the context
+	FinalizeOperations(context.Context, *FinalizeOperationsRequest) (*FinalizeOperationsResponse, error)
+	// CheckOperation checks the operation against the matched limits like SendOperation, but saves nothing
+	CheckOperation(context.Context, *CheckOperationRequest) (*CheckOperationResponse, error)
+	// GetUsage returns the usage of the total limits applied to the entities within their current periods
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	mustEmbedUnimplementedLimitsServiceServer()
+}
+
+// UnimplementedLimitsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLimitsServiceServer struct {
+}
+
+func (UnimplementedLimitsServiceServer) CreateLimit(context.Context, *CreateLimitRequest) (*Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLimit not implemented")
+}
+func (UnimplementedLimitsServiceServer) UpdateLimit(context.Context, *UpdateLimitRequest) (*Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLimit not implemented")
+}
+func (UnimplementedLimitsServiceServer) DeleteLimits(context.Context, *DeleteLimitsRequest) (*DeleteLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLimits not implemented")
+}
+func (UnimplementedLimitsServiceServer) GetLimits(context.Context, *GetLimitsRequest) (*GetLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLimits not implemented")
+}
+func (UnimplementedLimitsServiceServer) SendOperation(context.Context, *SendOperationRequest) (*SendOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOperation not implemented")
+}
+func (UnimplementedLimitsServiceServer) AppendOperation(context.Context, *AppendOperationRequest) (*AppendOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendOperation not implemented")
+}
+func (UnimplementedLimitsServiceServer) FinalizeOperations(context.Context, *FinalizeOperationsRequest) (*FinalizeOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeOperations not implemented")
+}
+func (UnimplementedLimitsServiceServer) CheckOperation(context.Context, *CheckOperationRequest) (*CheckOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckOperation not implemented")
+}
+func (UnimplementedLimitsServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedLimitsServiceServer) mustEmbedUnimplementedLimitsServiceServer() {}
+
+// UnsafeLimitsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LimitsServiceServer will
+// result in compilation errors.
+type UnsafeLimitsServiceServer interface {
+	mustEmbedUnimplementedLimitsServiceServer()
+}
+
+func RegisterLimitsServiceServer(s grpc.ServiceRegistrar, srv LimitsServiceServer) {
+	s.RegisterService(&LimitsService_ServiceDesc, srv)
+}
+
+func _LimitsService_CreateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).CreateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_CreateLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).CreateLimit(ctx, req.(*CreateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_UpdateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).UpdateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_UpdateLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).UpdateLimit(ctx, req.(*UpdateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_DeleteLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).DeleteLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_DeleteLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).DeleteLimits(ctx, req.(*DeleteLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_GetLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).GetLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_GetLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).GetLimits(ctx, req.(*GetLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_SendOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).SendOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_SendOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).SendOperation(ctx, req.(*SendOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_AppendOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).AppendOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_AppendOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).AppendOperation(ctx, req.(*AppendOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_FinalizeOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).FinalizeOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_FinalizeOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).FinalizeOperations(ctx, req.(*FinalizeOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_CheckOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).CheckOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_CheckOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).CheckOperation(ctx, req.(*CheckOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LimitsService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LimitsService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LimitsService_ServiceDesc is the grpc.ServiceDesc for LimitsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LimitsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "limits.LimitsService",
+	HandlerType: (*LimitsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLimit",
+			Handler:    _LimitsService_CreateLimit_Handler,
+		},
+		{
+			MethodName: "UpdateLimit",
+			Handler:    _LimitsService_UpdateLimit_Handler,
+		},
+		{
+			MethodName: "DeleteLimits",
+			Handler:    _LimitsService_DeleteLimits_Handler,
+		},
+		{
+			MethodName: "GetLimits",
+			Handler:    _LimitsService_GetLimits_Handler,
+		},
+		{
+			MethodName: "SendOperation",
+			Handler:    _LimitsService_SendOperation_Handler,
+		},
+		{
+			MethodName: "AppendOperation",
+			Handler:    _LimitsService_AppendOperation_Handler,
+		},
+		{
+			MethodName: "FinalizeOperations",
+			Handler:    _LimitsService_FinalizeOperations_Handler,
+		},
+		{
+			MethodName: "CheckOperation",
+			Handler:    _LimitsService_CheckOperation_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _LimitsService_GetUsage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "limits.proto",
+}
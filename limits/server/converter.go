@@ -0,0 +1,319 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/limits/domain"
+	pb "github.com/underbek/examples-go/limits/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	limitTypesToDomain = map[pb.LimitType]domain.LimitType{
+		pb.LimitType_LIMIT_TYPE_MIN_AMOUNT:   domain.LimitTypeMINAMOUNT,
+		pb.LimitType_LIMIT_TYPE_MAX_AMOUNT:   domain.LimitTypeMAXAMOUNT,
+		pb.LimitType_LIMIT_TYPE_TOTAL_AMOUNT: domain.LimitTypeTOTALAMOUNT,
+		pb.LimitType_LIMIT_TYPE_TOTAL_COUNT:  domain.LimitTypeTOTALCOUNT,
+	}
+
+	periodTypesToDomain = map[pb.PeriodType]domain.PeriodType{
+		pb.PeriodType_PERIOD_TYPE_CALENDAR_DAY:     domain.PeriodTypeCALENDARDAY,
+		pb.PeriodType_PERIOD_TYPE_CALENDAR_WEEK:    domain.PeriodTypeCALENDARWEEK,
+		pb.PeriodType_PERIOD_TYPE_CALENDAR_MONTH:   domain.PeriodTypeCALENDARMONTH,
+		pb.PeriodType_PERIOD_TYPE_CALENDAR_QUARTER: domain.PeriodTypeCALENDARQUARTER,
+		pb.PeriodType_PERIOD_TYPE_CALENDAR_YEAR:    domain.PeriodTypeCALENDARYEAR,
+		pb.PeriodType_PERIOD_TYPE_HOURS:            domain.PeriodTypeHOURS,
+		pb.PeriodType_PERIOD_TYPE_ROLLING:          domain.PeriodTypeROLLING,
+	}
+
+	finalizeStatusesToDomain = map[pb.FinalizeStatus]domain.OperationStatus{
+		pb.FinalizeStatus_FINALIZE_STATUS_COMMITTED: domain.OperationStatusCommitted,
+		pb.FinalizeStatus_FINALIZE_STATUS_ROLLBACK:  domain.OperationStatusRollback,
+	}
+
+	limitTypesFromDomain  = invert(limitTypesToDomain)
+	periodTypesFromDomain = invert(periodTypesToDomain)
+)
+
+func invert[K, V comparable](m map[K]V) map[V]K {
+	result := make(map[V]K, len(m))
+	for k, v := range m {
+		result[v] = k
+	}
+
+	return result
+}
+
+func toLimitType(limitType pb.LimitType) (domain.LimitType, error) {
+	result, ok := limitTypesToDomain[limitType]
+	if !ok {
+		return 0, ctxerrors.New(ctxerrors.TypeInvalidRequest, fmt.Sprintf("invalid limit type %s", limitType))
+	}
+
+	return result, nil
+}
+
+func toPeriodType(period *pb.PeriodType) (*domain.PeriodType, error) {
+	if period == nil {
+		return nil, nil
+	}
+
+	result, ok := periodTypesToDomain[*period]
+	if !ok {
+		return nil, ctxerrors.New(ctxerrors.TypeInvalidRequest, fmt.Sprintf("invalid period type %s", *period))
+	}
+
+	return &result, nil
+}
+
+func toDecimal(name, value string) (decimal.Decimal, error) {
+	result, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Decimal{}, ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, fmt.Sprintf("invalid %s %q", name, value))
+	}
+
+	return result, nil
+}
+
+func toAttributes(attributes []*pb.Attribute) domain.Attributes {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	result := make(domain.Attributes, 0, len(attributes))
+	for _, attribute := range attributes {
+		result = append(result, domain.Attribute{
+			Name:  attribute.GetName(),
+			Value: attribute.GetValue(),
+		})
+	}
+
+	return result
+}
+
+func fromAttributes(attributes domain.Attributes) []*pb.Attribute {
+	result := make([]*pb.Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		result = append(result, &pb.Attribute{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+		})
+	}
+
+	return result
+}
+
+func toLimit(
+	id uint64,
+	limitType pb.LimitType,
+	currency string,
+	anyCurrency bool,
+	value string,
+	entities []*pb.Attribute,
+	period *pb.PeriodType,
+	periodLength *uint32,
+	timezone *string,
+) (domain.Limit, error) {
+	domainLimitType, err := toLimitType(limitType)
+	if err != nil {
+		return domain.Limit{}, err
+	}
+
+	domainValue, err := toDecimal("limit value", value)
+	if err != nil {
+		return domain.Limit{}, err
+	}
+
+	domainPeriod, err := toPeriodType(period)
+	if err != nil {
+		return domain.Limit{}, err
+	}
+
+	return domain.Limit{
+		ID:           id,
+		LimitType:    domainLimitType,
+		Currency:     currency,
+		AnyCurrency:  anyCurrency,
+		Value:        domainValue,
+		Entities:     toAttributes(entities),
+		Period:       domainPeriod,
+		PeriodLength: periodLength,
+		Timezone:     timezone,
+	}, nil
+}
+
+func fromLimit(limit domain.Limit) *pb.Limit {
+	result := &pb.Limit{
+		Id:           limit.ID,
+		LimitType:    limitTypesFromDomain[limit.LimitType],
+		Currency:     limit.Currency,
+		AnyCurrency:  limit.AnyCurrency,
+		Value:        limit.Value.String(),
+		Entities:     fromAttributes(limit.Entities),
+		PeriodLength: limit.PeriodLength,
+		Timezone:     limit.Timezone,
+		CreatedAt:    timestamppb.New(limit.CreatedAt),
+		UpdatedAt:    timestamppb.New(limit.UpdatedAt),
+	}
+
+	if limit.Period != nil {
+		period := periodTypesFromDomain[*limit.Period]
+		result.Period = &period
+	}
+
+	return result
+}
+
+func toLimitsFilter(request *pb.GetLimitsRequest) (domain.LimitsFilter, error) {
+	filter := domain.LimitsFilter{
+		Currency: request.Currency,
+		Entities: toAttributes(request.GetEntities()),
+		Timezone: request.Timezone,
+		Limit:    request.Limit,
+		Offset:   request.Offset,
+	}
+
+	for _, limitType := range request.GetLimitTypes() {
+		domainLimitType, err := toLimitType(limitType)
+		if err != nil {
+			return domain.LimitsFilter{}, err
+		}
+
+		filter.LimitTypes = append(filter.LimitTypes, domainLimitType)
+	}
+
+	period, err := toPeriodType(request.Period)
+	if err != nil {
+		return domain.LimitsFilter{}, err
+	}
+
+	filter.Period = period
+
+	return filter, nil
+}
+
+func toOperationInfo(amount *pb.Amount, entities []*pb.Attribute) (domain.OperationInfo, error) {
+	if amount == nil {
+		return domain.OperationInfo{}, ctxerrors.New(ctxerrors.TypeInvalidRequest, "operation amount is empty")
+	}
+
+	value, err := toDecimal("operation amount", amount.GetValue())
+	if err != nil {
+		return domain.OperationInfo{}, err
+	}
+
+	return domain.OperationInfo{
+		Amount: domain.Amount{
+			Currency: amount.GetCurrency(),
+			Value:    value,
+		},
+		Meta: toAttributes(entities),
+	}, nil
+}
+
+func toFinalizeOperationsInfo(request *pb.FinalizeOperationsRequest) (domain.FinalizeOperationsInfo, error) {
+	status, ok := finalizeStatusesToDomain[request.GetStatus()]
+	if !ok {
+		return domain.FinalizeOperationsInfo{}, ctxerrors.New(
+			ctxerrors.TypeInvalidRequest,
+			fmt.Sprintf("invalid finalize status %s", request.GetStatus()),
+		)
+	}
+
+	return domain.FinalizeOperationsInfo{
+		ContextID: request.GetContextId(),
+		Status:    status,
+	}, nil
+}
+
+func fromOperationCheck(check domain.OperationCheck) *pb.CheckOperationResponse {
+	response := &pb.CheckOperationResponse{
+		Allowed:  len(check.Exceeded) == 0,
+		Exceeded: make([]*pb.ExceededLimit, 0, len(check.Exceeded)),
+		Counters: make([]*pb.CounterHeadroom, 0, len(check.Counters)),
+	}
+
+	for _, exceeded := range check.Exceeded {
+		limit := &pb.ExceededLimit{
+			LimitId:    exceeded.LimitID,
+			LimitType:  limitTypesFromDomain[exceeded.LimitType],
+			LimitValue: exceeded.LimitValue.String(),
+			NewValue:   exceeded.NewValue.String(),
+		}
+
+		if exceeded.Period != nil {
+			period := periodTypesFromDomain[*exceeded.Period]
+			limit.Period = &period
+		}
+
+		response.Exceeded = append(response.Exceeded, limit)
+	}
+
+	for _, counter := range check.Counters {
+		response.Counters = append(response.Counters, &pb.CounterHeadroom{
+			LimitId:    counter.LimitID,
+			CounterId:  counter.CounterID,
+			LimitType:  limitTypesFromDomain[counter.LimitType],
+			Period:     periodTypesFromDomain[counter.Period],
+			StartTime:  timestamppb.New(counter.StartTime),
+			EndTime:    timestamppb.New(counter.EndTime),
+			LimitValue: counter.LimitValue.String(),
+			Value:      counter.Value.String(),
+			Remaining:  counter.Remaining.String(),
+		})
+	}
+
+	return response
+}
+
+func toUsageFilter(request *pb.GetUsageRequest) (domain.UsageFilter, error) {
+	filter := domain.UsageFilter{
+		Entities: toAttributes(request.GetEntities()),
+		Currency: request.Currency,
+	}
+
+	for _, limitType := range request.GetLimitTypes() {
+		domainLimitType, err := toLimitType(limitType)
+		if err != nil {
+			return domain.UsageFilter{}, err
+		}
+
+		filter.LimitTypes = append(filter.LimitTypes, domainLimitType)
+	}
+
+	period, err := toPeriodType(request.Period)
+	if err != nil {
+		return domain.UsageFilter{}, err
+	}
+
+	filter.Period = period
+
+	return filter, nil
+}
+
+func fromLimitUsages(usages []domain.LimitUsage) *pb.GetUsageResponse {
+	response := &pb.GetUsageResponse{
+		Usages: make([]*pb.LimitUsage, 0, len(usages)),
+	}
+
+	for _, usage := range usages {
+		response.Usages = append(response.Usages, &pb.LimitUsage{
+			LimitId:    usage.LimitID,
+			CounterId:  usage.CounterID,
+			LimitType:  limitTypesFromDomain[usage.LimitType],
+			Currency:   usage.Currency,
+			Period:     periodTypesFromDomain[usage.Period],
+			StartTime:  timestamppb.New(usage.StartTime),
+			EndTime:    timestamppb.New(usage.EndTime),
+			LimitValue: usage.LimitValue.String(),
+			Value:      usage.Value.String(),
+			Pending:    usage.Pending.String(),
+			Committed:  usage.Committed.String(),
+			Remaining:  usage.Remaining.String(),
+		})
+	}
+
+	return response
+}
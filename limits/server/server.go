@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/limits/domain"
+	pb "github.com/underbek/examples-go/limits/proto"
+	"github.com/underbek/examples-go/transport/grpcserver"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockery --name Service --structname ServiceMock --filename service_mock.go --inpackage
+type Service interface {
+	CreateLimit(context.Context, domain.Limit) (domain.Limit, error)
+	UpdateLimit(context.Context, domain.Limit) (domain.Limit, error)
+	DeleteLimits(context.Context, []uint64) error
+	GetLimits(context.Context, domain.LimitsFilter) ([]domain.Limit, uint64, error)
+
+	SendOperation(context.Context, domain.OperationInfo) (uint64, error)
+	AppendOperation(context.Context, domain.AppendOperationInfo) (uint64, error)
+	FinalizeOperations(context.Context, domain.FinalizeOperationsInfo) error
+	CheckOperation(context.Context, domain.OperationInfo) (domain.OperationCheck, error)
+	GetUsage(context.Context, domain.UsageFilter) ([]domain.LimitUsage, error)
+}
+
+type server struct {
+	// Need for implementation interface pb.LimitsServiceServer
+	pb.UnimplementedLimitsServiceServer
+	service Service
+}
+
+func New(service Service) *server {
+	return &server{
+		service: service,
+	}
+}
+
+// Register registers the limits service on the grpc server
+func Register(grpcServer *grpcserver.GRPCServer, service Service) {
+	pb.RegisterLimitsServiceServer(grpcServer.Server, New(service))
+}
+
+func (s *server) CreateLimit(ctx context.Context, request *pb.CreateLimitRequest) (*pb.Limit, error) {
+	limit, err := toLimit(
+		0,
+		request.GetLimitType(),
+		request.GetCurrency(),
+		request.GetAnyCurrency(),
+		request.GetValue(),
+		request.GetEntities(),
+		request.Period,
+		request.PeriodLength,
+		request.Timezone,
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	limit, err = s.service.CreateLimit(ctx, limit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return fromLimit(limit), nil
+}
+
+func (s *server) UpdateLimit(ctx context.Context, request *pb.UpdateLimitRequest) (*pb.Limit, error) {
+	limit, err := toLimit(
+		request.GetId(),
+		request.GetLimitType(),
+		request.GetCurrency(),
+		request.GetAnyCurrency(),
+		request.GetValue(),
+		request.GetEntities(),
+		request.Period,
+		request.PeriodLength,
+		request.Timezone,
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	limit, err = s.service.UpdateLimit(ctx, limit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return fromLimit(limit), nil
+}
+
+func (s *server) DeleteLimits(ctx context.Context, request *pb.DeleteLimitsRequest) (*pb.DeleteLimitsResponse, error) {
+	if len(request.GetIds()) == 0 {
+		return nil, toStatusError(ctxerrors.New(ctxerrors.TypeInvalidRequest, "limit ids are empty"))
+	}
+
+	if err := s.service.DeleteLimits(ctx, request.GetIds()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.DeleteLimitsResponse{}, nil
+}
+
+func (s *server) GetLimits(ctx context.Context, request *pb.GetLimitsRequest) (*pb.GetLimitsResponse, error) {
+	filter, err := toLimitsFilter(request)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	limits, total, err := s.service.GetLimits(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetLimitsResponse{
+		Limits: make([]*pb.Limit, 0, len(limits)),
+		Total:  total,
+	}
+
+	for _, limit := range limits {
+		response.Limits = append(response.Limits, fromLimit(limit))
+	}
+
+	return response, nil
+}
+
+func (s *server) SendOperation(ctx context.Context, request *pb.SendOperationRequest) (*pb.SendOperationResponse, error) {
+	info, err := toOperationInfo(request.GetAmount(), request.GetEntities())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	contextID, err := s.service.SendOperation(ctx, info)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.SendOperationResponse{ContextId: contextID}, nil
+}
+
+func (s *server) AppendOperation(ctx context.Context, request *pb.AppendOperationRequest) (*pb.AppendOperationResponse, error) {
+	contextID, err := s.service.AppendOperation(ctx, domain.AppendOperationInfo{
+		ContextID: request.GetContextId(),
+		Meta:      toAttributes(request.GetEntities()),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.AppendOperationResponse{ContextId: contextID}, nil
+}
+
+func (s *server) FinalizeOperations(
+	ctx context.Context,
+	request *pb.FinalizeOperationsRequest,
+) (*pb.FinalizeOperationsResponse, error) {
+	info, err := toFinalizeOperationsInfo(request)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if err = s.service.FinalizeOperations(ctx, info); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.FinalizeOperationsResponse{}, nil
+}
+
+func (s *server) CheckOperation(ctx context.Context, request *pb.CheckOperationRequest) (*pb.CheckOperationResponse, error) {
+	info, err := toOperationInfo(request.GetAmount(), request.GetEntities())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	check, err := s.service.CheckOperation(ctx, info)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return fromOperationCheck(check), nil
+}
+
+func (s *server) GetUsage(ctx context.Context, request *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	filter, err := toUsageFilter(request)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	usages, err := s.service.GetUsage(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return fromLimitUsages(usages), nil
+}
+
+func toStatusError(err error) error {
+	return status.Error(ctxerrors.ParseGRPCError(err))
+}
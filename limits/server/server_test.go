@@ -0,0 +1,283 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/limits/domain"
+	pb "github.com/underbek/examples-go/limits/proto"
+	"github.com/underbek/examples-go/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_CreateLimit(t *testing.T) {
+	createdAt := time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	service := NewServiceMock(t)
+	service.On("CreateLimit", mock.Anything, domain.Limit{
+		LimitType: domain.LimitTypeTOTALAMOUNT,
+		Currency:  "USD",
+		Value:     decimal.RequireFromString("100.5"),
+		Entities:  domain.Attributes{{Name: "user_id", Value: "1"}},
+		Period:    utils.ToPtr(domain.PeriodTypeCALENDARDAY),
+		Timezone:  utils.ToPtr("UTC"),
+	}).Return(domain.Limit{
+		ID:        1,
+		LimitType: domain.LimitTypeTOTALAMOUNT,
+		Currency:  "USD",
+		Value:     decimal.RequireFromString("100.5"),
+		Entities:  domain.Attributes{{Name: "user_id", Value: "1"}},
+		Period:    utils.ToPtr(domain.PeriodTypeCALENDARDAY),
+		Timezone:  utils.ToPtr("UTC"),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}, nil).Once()
+
+	res, err := New(service).CreateLimit(context.Background(), &pb.CreateLimitRequest{
+		LimitType: pb.LimitType_LIMIT_TYPE_TOTAL_AMOUNT,
+		Currency:  "USD",
+		Value:     "100.5",
+		Entities:  []*pb.Attribute{{Name: "user_id", Value: "1"}},
+		Period:    utils.ToPtr(pb.PeriodType_PERIOD_TYPE_CALENDAR_DAY),
+		Timezone:  utils.ToPtr("UTC"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), res.GetId())
+	assert.Equal(t, pb.LimitType_LIMIT_TYPE_TOTAL_AMOUNT, res.GetLimitType())
+	assert.Equal(t, "100.5", res.GetValue())
+	assert.Equal(t, pb.PeriodType_PERIOD_TYPE_CALENDAR_DAY, res.GetPeriod())
+	assert.Equal(t, "UTC", res.GetTimezone())
+	assert.Equal(t, createdAt, res.GetCreatedAt().AsTime())
+	require.Len(t, res.GetEntities(), 1)
+	assert.Equal(t, "user_id", res.GetEntities()[0].GetName())
+}
+
+func TestServer_InvalidRequest(t *testing.T) {
+	srv := New(NewServiceMock(t))
+	ctx := context.Background()
+
+	_, err := srv.CreateLimit(ctx, &pb.CreateLimitRequest{Value: "100"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.CreateLimit(ctx, &pb.CreateLimitRequest{
+		LimitType: pb.LimitType_LIMIT_TYPE_MAX_AMOUNT,
+		Value:     "one hundred",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.GetLimits(ctx, &pb.GetLimitsRequest{
+		Period: utils.ToPtr(pb.PeriodType_PERIOD_TYPE_UNSPECIFIED),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.SendOperation(ctx, &pb.SendOperationRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.DeleteLimits(ctx, &pb.DeleteLimitsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.FinalizeOperations(ctx, &pb.FinalizeOperationsRequest{ContextId: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{
+			name: "invalid request",
+			err:  ctxerrors.New(ctxerrors.TypeInvalidRequest, "entities is empty"),
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "not found",
+			err:  ctxerrors.Wrap(errors.New("no rows"), ctxerrors.TypeNotFound, "limit not found"),
+			code: codes.NotFound,
+		},
+		{
+			name: "exceeded limit",
+			err:  ctxerrors.New(ctxerrors.TypeInternal, "new counter value is greater than limit"),
+			code: codes.Unavailable,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("connection refused"),
+			code: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewServiceMock(t)
+			service.On("SendOperation", mock.Anything, domain.OperationInfo{
+				Amount: domain.Amount{Currency: "USD", Value: decimal.RequireFromString("10")},
+				Meta:   domain.Attributes{{Name: "user_id", Value: "1"}},
+			}).Return(uint64(0), tt.err).Once()
+
+			_, err := New(service).SendOperation(context.Background(), &pb.SendOperationRequest{
+				Amount:   &pb.Amount{Currency: "USD", Value: "10"},
+				Entities: []*pb.Attribute{{Name: "user_id", Value: "1"}},
+			})
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+		})
+	}
+}
+
+func TestServer_GetLimits(t *testing.T) {
+	service := NewServiceMock(t)
+	service.On("GetLimits", mock.Anything, domain.LimitsFilter{
+		LimitTypes: []domain.LimitType{domain.LimitTypeMINAMOUNT, domain.LimitTypeTOTALCOUNT},
+		Currency:   utils.ToPtr("EUR"),
+		Limit:      utils.ToPtr(uint64(10)),
+	}).Return([]domain.Limit{
+		{ID: 1, LimitType: domain.LimitTypeMINAMOUNT, Currency: "EUR", Value: decimal.RequireFromString("1")},
+	}, uint64(5), nil).Once()
+
+	res, err := New(service).GetLimits(context.Background(), &pb.GetLimitsRequest{
+		LimitTypes: []pb.LimitType{pb.LimitType_LIMIT_TYPE_MIN_AMOUNT, pb.LimitType_LIMIT_TYPE_TOTAL_COUNT},
+		Currency:   utils.ToPtr("EUR"),
+		Limit:      utils.ToPtr(uint64(10)),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), res.GetTotal())
+	require.Len(t, res.GetLimits(), 1)
+	assert.Equal(t, pb.LimitType_LIMIT_TYPE_MIN_AMOUNT, res.GetLimits()[0].GetLimitType())
+	assert.Nil(t, res.GetLimits()[0].Period)
+}
+
+func TestServer_Operations(t *testing.T) {
+	service := NewServiceMock(t)
+	service.On("AppendOperation", mock.Anything, domain.AppendOperationInfo{
+		ContextID: 7,
+		Meta:      domain.Attributes{{Name: "merchant_id", Value: "2"}},
+	}).Return(uint64(7), nil).Once()
+	service.On("FinalizeOperations", mock.Anything, domain.FinalizeOperationsInfo{
+		ContextID: 7,
+		Status:    domain.OperationStatusRollback,
+	}).Return(nil).Once()
+
+	srv := New(service)
+
+	appended, err := srv.AppendOperation(context.Background(), &pb.AppendOperationRequest{
+		ContextId: 7,
+		Entities:  []*pb.Attribute{{Name: "merchant_id", Value: "2"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), appended.GetContextId())
+
+	_, err = srv.FinalizeOperations(context.Background(), &pb.FinalizeOperationsRequest{
+		ContextId: 7,
+		Status:    pb.FinalizeStatus_FINALIZE_STATUS_ROLLBACK,
+	})
+	require.NoError(t, err)
+}
+
+func TestServer_CheckOperation(t *testing.T) {
+	start := time.Date(2023, 5, 13, 0, 0, 0, 0, time.UTC)
+
+	service := NewServiceMock(t)
+	service.On("CheckOperation", mock.Anything, domain.OperationInfo{
+		Amount: domain.Amount{Currency: "EUR", Value: decimal.RequireFromString("60")},
+		Meta:   domain.Attributes{{Name: "user_id", Value: "1"}},
+	}).Return(domain.OperationCheck{
+		Exceeded: []domain.ExceededLimit{
+			{
+				LimitID:    1,
+				LimitType:  domain.LimitTypeMAXAMOUNT,
+				LimitValue: decimal.RequireFromString("50"),
+				NewValue:   decimal.RequireFromString("60"),
+			},
+		},
+		Counters: []domain.CounterHeadroom{
+			{
+				LimitID:    2,
+				CounterID:  utils.ToPtr(uint64(20)),
+				LimitType:  domain.LimitTypeTOTALAMOUNT,
+				Period:     domain.PeriodTypeCALENDARDAY,
+				StartTime:  start,
+				EndTime:    start.Add(24 * time.Hour),
+				LimitValue: decimal.RequireFromString("1000"),
+				Value:      decimal.RequireFromString("900"),
+				Remaining:  decimal.RequireFromString("100"),
+			},
+		},
+	}, nil).Once()
+
+	res, err := New(service).CheckOperation(context.Background(), &pb.CheckOperationRequest{
+		Amount:   &pb.Amount{Currency: "EUR", Value: "60"},
+		Entities: []*pb.Attribute{{Name: "user_id", Value: "1"}},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, res.GetAllowed())
+	require.Len(t, res.GetExceeded(), 1)
+	assert.Equal(t, pb.LimitType_LIMIT_TYPE_MAX_AMOUNT, res.GetExceeded()[0].GetLimitType())
+	assert.Nil(t, res.GetExceeded()[0].Period)
+	assert.Equal(t, "60", res.GetExceeded()[0].GetNewValue())
+
+	require.Len(t, res.GetCounters(), 1)
+	assert.Equal(t, uint64(20), res.GetCounters()[0].GetCounterId())
+	assert.Equal(t, pb.PeriodType_PERIOD_TYPE_CALENDAR_DAY, res.GetCounters()[0].GetPeriod())
+	assert.Equal(t, "100", res.GetCounters()[0].GetRemaining())
+	assert.Equal(t, start, res.GetCounters()[0].GetStartTime().AsTime())
+}
+
+func TestServer_GetUsage(t *testing.T) {
+	service := NewServiceMock(t)
+	service.On("GetUsage", mock.Anything, domain.UsageFilter{
+		Entities:   domain.Attributes{{Name: "user_id", Value: "1"}},
+		LimitTypes: []domain.LimitType{domain.LimitTypeTOTALAMOUNT},
+		Period:     utils.ToPtr(domain.PeriodTypeCALENDARMONTH),
+	}).Return([]domain.LimitUsage{
+		{
+			CounterHeadroom: domain.CounterHeadroom{
+				LimitID:    1,
+				LimitType:  domain.LimitTypeTOTALAMOUNT,
+				Period:     domain.PeriodTypeCALENDARMONTH,
+				LimitValue: decimal.RequireFromString("1000"),
+				Value:      decimal.RequireFromString("300"),
+				Remaining:  decimal.RequireFromString("700"),
+			},
+			Currency:  "USD",
+			Pending:   decimal.RequireFromString("100"),
+			Committed: decimal.RequireFromString("200"),
+		},
+	}, nil).Once()
+
+	res, err := New(service).GetUsage(context.Background(), &pb.GetUsageRequest{
+		Entities:   []*pb.Attribute{{Name: "user_id", Value: "1"}},
+		LimitTypes: []pb.LimitType{pb.LimitType_LIMIT_TYPE_TOTAL_AMOUNT},
+		Period:     utils.ToPtr(pb.PeriodType_PERIOD_TYPE_CALENDAR_MONTH),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.GetUsages(), 1)
+	usage := res.GetUsages()[0]
+	assert.Nil(t, usage.CounterId)
+	assert.Equal(t, "USD", usage.GetCurrency())
+	assert.Equal(t, pb.PeriodType_PERIOD_TYPE_CALENDAR_MONTH, usage.GetPeriod())
+	assert.Equal(t, "100", usage.GetPending())
+	assert.Equal(t, "200", usage.GetCommitted())
+	assert.Equal(t, "700", usage.GetRemaining())
+
+	_, err = New(service).GetUsage(context.Background(), &pb.GetUsageRequest{
+		LimitTypes: []pb.LimitType{pb.LimitType_LIMIT_TYPE_UNSPECIFIED},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/underbek/examples-go/limits/domain"
+)
+
+// CheckOperation checks the operation against the matched limits like SendOperation, but saves nothing.
+// It returns every exceeded limit and the headroom of the counters before the operation.
+func (s *service) CheckOperation(ctx context.Context, info domain.OperationInfo) (domain.OperationCheck, error) {
+	err := validateOperationInfo(info)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("validate operation info failed")
+
+		return domain.OperationCheck{}, err
+	}
+
+	now := s.timeProvider.Now()
+	st := s.createStorage(s.db)
+
+	limits, err := st.MatchLimits(ctx, info.Amount.Currency, info.Meta)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("match limits failed")
+
+		return domain.OperationCheck{}, err
+	}
+
+	rates, err := s.getRates(ctx, info.Amount.Currency, limits, nil)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get rates failed")
+
+		return domain.OperationCheck{}, err
+	}
+
+	static, dynamic := splitLimits(limits)
+
+	result := domain.OperationCheck{
+		Exceeded: make([]domain.ExceededLimit, 0),
+		Counters: make([]domain.CounterHeadroom, 0, len(dynamic)),
+	}
+
+	for _, limit := range static {
+		amount := convertAmount(limit, info.Amount, rates)
+		if checkStaticLimit(limit, amount) != nil {
+			result.Exceeded = append(result.Exceeded, domain.ExceededLimit{
+				LimitID:    limit.ID,
+				LimitType:  limit.LimitType,
+				LimitValue: limit.Value,
+				NewValue:   amount,
+			})
+		}
+	}
+
+	if len(dynamic) == 0 {
+		return result, nil
+	}
+
+	counters, err := s.GenerateCounters(ctx, dynamic, now)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("generate counters failed")
+
+		return domain.OperationCheck{}, err
+	}
+
+	values, err := st.GetCountersValues(ctx, counters, now)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get counters values failed")
+
+		return domain.OperationCheck{}, err
+	}
+
+	valuesByLimit := make(map[uint64]domain.CounterValue, len(values))
+	for _, value := range values {
+		valuesByLimit[value.LimitID] = value
+	}
+
+	for i, limit := range dynamic {
+		value := valuesByLimit[limit.ID]
+		headroom := newCounterHeadroom(limit, counters[i], value, now)
+		result.Counters = append(result.Counters, headroom)
+
+		newValue := headroom.Value.Add(operationIncrement(limit, convertAmount(limit, info.Amount, rates)))
+		if newValue.GreaterThan(limit.Value) {
+			result.Exceeded = append(result.Exceeded, domain.ExceededLimit{
+				LimitID:    limit.ID,
+				LimitType:  limit.LimitType,
+				Period:     limit.Period,
+				LimitValue: limit.Value,
+				NewValue:   newValue,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func newCounterHeadroom(limit domain.Limit, counter domain.Counter, value domain.CounterValue, now time.Time) domain.CounterHeadroom {
+	headroom := domain.CounterHeadroom{
+		LimitID:    limit.ID,
+		CounterID:  value.CounterID,
+		LimitType:  limit.LimitType,
+		Period:     *limit.Period,
+		StartTime:  counter.StartTime,
+		EndTime:    counter.EndTime,
+		LimitValue: limit.Value,
+		Value:      value.Value,
+		Remaining:  decimal.Max(limit.Value.Sub(value.Value), decimal.Zero),
+	}
+
+	// the rolling counter only groups the operations, the limit is counted within the window before now
+	if *limit.Period == domain.PeriodTypeROLLING {
+		headroom.StartTime = now.UTC().Add(-time.Duration(*limit.PeriodLength) * time.Hour)
+		headroom.EndTime = now.UTC()
+	}
+
+	return headroom
+}
+
+func operationIncrement(limit domain.Limit, amount decimal.Decimal) decimal.Decimal {
+	if limit.LimitType == domain.LimitTypeTOTALCOUNT {
+		return decimal.NewFromInt(1)
+	}
+
+	return amount
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/limits/config"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/limits/fx"
+	"github.com/underbek/examples-go/limits/time_provider"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/utils"
+)
+
+// checkStorage serves the read queries of CheckOperation, any write panics on the nil Storage
+type checkStorage struct {
+	Storage
+	limits []domain.Limit
+	values []domain.CounterValue
+}
+
+func (s *checkStorage) MatchLimits(context.Context, string, domain.Attributes) ([]domain.Limit, error) {
+	return s.limits, nil
+}
+
+func (s *checkStorage) GetCountersValues(context.Context, []domain.Counter, time.Time) ([]domain.CounterValue, error) {
+	return s.values, nil
+}
+
+func TestService_CheckOperation(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 5, 13, 15, 39, 0, 0, time.UTC)
+	tp := time_provider.NewTimeProviderMock(t)
+	tp.On("Now").Return(now).Once()
+
+	st := &checkStorage{
+		limits: []domain.Limit{
+			{ID: 1, LimitType: domain.LimitTypeMAXAMOUNT, Currency: "EUR", Value: decimal.NewFromInt(50)},
+			{ID: 2, LimitType: domain.LimitTypeMINAMOUNT, Currency: "EUR", Value: decimal.NewFromInt(10)},
+			{
+				ID:        3,
+				LimitType: domain.LimitTypeTOTALAMOUNT,
+				Currency:  "EUR",
+				Value:     decimal.NewFromInt(1000),
+				Period:    utils.ToPtr(domain.PeriodTypeCALENDARDAY),
+				Timezone:  utils.ToPtr("UTC"),
+			},
+			{
+				ID:           4,
+				LimitType:    domain.LimitTypeTOTALCOUNT,
+				Currency:     "EUR",
+				Value:        decimal.NewFromInt(3),
+				Period:       utils.ToPtr(domain.PeriodTypeROLLING),
+				PeriodLength: utils.ToPtr(uint32(24)),
+				Timezone:     utils.ToPtr("UTC"),
+			},
+		},
+		values: []domain.CounterValue{
+			{LimitID: 3, CounterID: utils.ToPtr(uint64(30)), Value: decimal.NewFromInt(900)},
+			{LimitID: 4, Value: decimal.NewFromInt(3)},
+		},
+	}
+
+	srv := New(lg, config.StorageTransaction{}, nil, func(goKitPgx.ExtContext) Storage { return st }, tp)
+
+	result, err := srv.CheckOperation(context.Background(), domain.OperationInfo{
+		Amount: domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(60)},
+		Meta:   domain.Attributes{{Name: "merchant_id", Value: "1"}},
+	})
+	require.NoError(t, err)
+
+	exceeded := make([]uint64, 0, len(result.Exceeded))
+	for _, limit := range result.Exceeded {
+		exceeded = append(exceeded, limit.LimitID)
+	}
+
+	assert.Equal(t, []uint64{1, 4}, exceeded)
+	assert.True(t, decimal.NewFromInt(4).Equal(result.Exceeded[1].NewValue))
+
+	require.Len(t, result.Counters, 2)
+
+	daily := result.Counters[0]
+	assert.Equal(t, utils.ToPtr(uint64(30)), daily.CounterID)
+	assert.True(t, decimal.NewFromInt(100).Equal(daily.Remaining))
+	assert.Equal(t, time.Date(2023, 5, 13, 0, 0, 0, 0, time.UTC), daily.StartTime)
+	assert.Equal(t, time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC), daily.EndTime)
+
+	rolling := result.Counters[1]
+	assert.Nil(t, rolling.CounterID)
+	assert.True(t, decimal.Zero.Equal(rolling.Remaining))
+	assert.Equal(t, now.Add(-24*time.Hour), rolling.StartTime)
+	assert.Equal(t, now, rolling.EndTime)
+}
+
+func TestService_CheckOperation_InvalidOperation(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	srv := New(lg, config.StorageTransaction{}, nil, nil, time_provider.NewTimeProviderMock(t))
+
+	_, err = srv.CheckOperation(context.Background(), domain.OperationInfo{
+		Amount: domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(60)},
+	})
+	require.ErrorContains(t, err, "entities is empty")
+}
+
+func TestService_CheckOperation_AnyCurrency(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 5, 13, 15, 39, 0, 0, time.UTC)
+	tp := time_provider.NewTimeProviderMock(t)
+	tp.On("Now").Return(now).Once()
+
+	st := &checkStorage{
+		limits: []domain.Limit{
+			{ID: 1, LimitType: domain.LimitTypeMAXAMOUNT, Currency: "EUR", Value: decimal.NewFromInt(100)},
+			{ID: 2, LimitType: domain.LimitTypeMAXAMOUNT, Currency: "USD", AnyCurrency: true, Value: decimal.NewFromInt(100)},
+			{
+				ID:          3,
+				LimitType:   domain.LimitTypeTOTALAMOUNT,
+				Currency:    "USD",
+				AnyCurrency: true,
+				Value:       decimal.NewFromInt(1000),
+				Period:      utils.ToPtr(domain.PeriodTypeCALENDARDAY),
+				Timezone:    utils.ToPtr("UTC"),
+			},
+		},
+		values: []domain.CounterValue{
+			{LimitID: 3, CounterID: utils.ToPtr(uint64(30)), Value: decimal.NewFromInt(950)},
+		},
+	}
+
+	srv := New(
+		lg,
+		config.StorageTransaction{},
+		nil,
+		func(goKitPgx.ExtContext) Storage { return st },
+		tp,
+		WithRateProvider(fx.NewStatic(map[string]decimal.Decimal{"EUR/USD": decimal.RequireFromString("1.1")})),
+	)
+
+	result, err := srv.CheckOperation(context.Background(), domain.OperationInfo{
+		Amount: domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(100)},
+		Meta:   domain.Attributes{{Name: "user_id", Value: "1"}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Exceeded, 2)
+	assert.Equal(t, uint64(2), result.Exceeded[0].LimitID)
+	assert.True(t, decimal.NewFromInt(110).Equal(result.Exceeded[0].NewValue))
+	assert.Equal(t, uint64(3), result.Exceeded[1].LimitID)
+	assert.True(t, decimal.NewFromInt(1060).Equal(result.Exceeded[1].NewValue))
+}
+
+func TestService_CheckOperation_WithoutRateProvider(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	tp := time_provider.NewTimeProviderMock(t)
+	tp.On("Now").Return(time.Now()).Once()
+
+	st := &checkStorage{
+		limits: []domain.Limit{
+			{ID: 1, LimitType: domain.LimitTypeMAXAMOUNT, Currency: "USD", AnyCurrency: true, Value: decimal.NewFromInt(100)},
+		},
+	}
+
+	srv := New(lg, config.StorageTransaction{}, nil, func(goKitPgx.ExtContext) Storage { return st }, tp)
+
+	_, err = srv.CheckOperation(context.Background(), domain.OperationInfo{
+		Amount: domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(10)},
+		Meta:   domain.Attributes{{Name: "user_id", Value: "1"}},
+	})
+	require.ErrorContains(t, err, "rate provider is not set")
+}
@@ -34,8 +34,6 @@ func (s *service) CreateLimit(ctx context.Context, limit domain.Limit) (domain.L
 }
 
 func (s *service) DeleteLimits(ctx context.Context, ids []uint64) error {
-	var scopeIDs []uint64
-
 	tx, err := s.db.Begin(ctx, &pgx.TxOptions{
 		IsoLevel: pgx.ReadCommitted,
 	})
@@ -57,7 +55,7 @@ func (s *service) DeleteLimits(ctx context.Context, ids []uint64) error {
 	}()
 
 	st := s.createStorage(tx)
-	err = s.deleteLimits(ctx, st, ids, scopeIDs)
+	err = s.deleteLimits(ctx, st, ids)
 	if err != nil {
 		return err
 	}
@@ -134,7 +132,7 @@ func (s *service) UpdateLimit(ctx context.Context, limit domain.Limit) (domain.L
 	} else {
 		s.logger.WithCtx(ctx).Debug("delete old limit and create new to update")
 
-		err = s.deleteLimits(ctx, st, []uint64{limit.ID}, nil)
+		err = s.deleteLimits(ctx, st, []uint64{limit.ID})
 		if err != nil {
 			return domain.Limit{}, err
 		}
@@ -13,6 +13,15 @@ import (
 func (s *service) createOperationEntities(ctx context.Context, info domain.OperationInfo) (uint64, []uint64, uint64, error) {
 	now := s.timeProvider.Now()
 
+	resolved, err := s.resolveRates(ctx, info.Amount.Currency, info.Meta)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("resolve rates failed")
+
+		return 0, nil, 0, err
+	}
+
 	tx, err := s.db.Begin(ctx, &pgx.TxOptions{
 		IsoLevel: pgx.ReadCommitted,
 	})
@@ -62,8 +71,17 @@ func (s *service) createOperationEntities(ctx context.Context, info domain.Opera
 		return 0, nil, 0, err
 	}
 
+	rates, err := s.getRates(ctx, info.Amount.Currency, limits, resolved)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get rates failed")
+
+		return 0, nil, 0, err
+	}
+
 	static, dynamic := splitLimits(limits)
-	err = checkStaticLimits(static, info.Amount.Value)
+	err = checkStaticLimits(static, info.Amount, rates)
 	if err != nil {
 		s.logger.WithCtx(ctx).
 			WithError(err).
@@ -77,6 +95,7 @@ func (s *service) createOperationEntities(ctx context.Context, info domain.Opera
 		Value:     info.Amount.Value,
 		Currency:  info.Amount.Currency,
 		Status:    domain.OperationStatusNew,
+		Rates:     rates,
 	}
 
 	operation, err = st.CreateOperation(ctx, operation)
@@ -125,6 +144,15 @@ func (s *service) createOperationEntities(ctx context.Context, info domain.Opera
 func (s *service) updateOperationEntities(ctx context.Context, info domain.AppendOperationInfo) ([]uint64, uint64, domain.Context, error) {
 	now := s.timeProvider.Now()
 
+	resolved, err := s.resolveAppendRates(ctx, info)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("resolve rates failed")
+
+		return nil, 0, domain.Context{}, err
+	}
+
 	tx, err := s.db.Begin(ctx, &pgx.TxOptions{
 		IsoLevel: pgx.ReadCommitted,
 	})
@@ -217,8 +245,18 @@ func (s *service) updateOperationEntities(ctx context.Context, info domain.Appen
 	}
 
 	limits := filterOldLimits(newLimits, oldLimits)
+
+	rates, err := s.getRates(ctx, oldOperation.Currency, limits, resolved)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get rates failed")
+
+		return nil, 0, domain.Context{}, err
+	}
+
 	static, dynamic := splitLimits(limits)
-	err = checkStaticLimits(static, oldOperation.Value)
+	err = checkStaticLimits(static, domain.Amount{Currency: oldOperation.Currency, Value: oldOperation.Value}, rates)
 	if err != nil {
 		s.logger.WithCtx(ctx).
 			WithError(err).
@@ -232,6 +270,7 @@ func (s *service) updateOperationEntities(ctx context.Context, info domain.Appen
 		Value:     oldOperation.Value,
 		Currency:  oldOperation.Currency,
 		Status:    domain.OperationStatusNew,
+		Rates:     rates,
 	}
 
 	operation, err = st.CreateOperation(ctx, operation)
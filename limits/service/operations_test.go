@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/limits/config"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/limits/time_provider"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+)
+
+// txDB tracks whether a transaction is open, any query panics on the nil Storage
+type txDB struct {
+	goKitPgx.Storage
+	open bool
+}
+
+func (d *txDB) Begin(context.Context, *pgx.TxOptions) (goKitPgx.Transaction, error) {
+	d.open = true
+	return &txFake{db: d}, nil
+}
+
+type txFake struct {
+	goKitPgx.Transaction
+	db *txDB
+}
+
+func (t *txFake) Commit(context.Context) error {
+	t.db.open = false
+	return nil
+}
+
+func (t *txFake) Rollback(context.Context) error {
+	if !t.db.open {
+		return pgx.ErrTxClosed
+	}
+
+	t.db.open = false
+
+	return nil
+}
+
+// txRateProvider records whether the rates are requested inside the transaction
+type txRateProvider struct {
+	db   *txDB
+	inTx []bool
+}
+
+func (p *txRateProvider) Rate(context.Context, string, string) (decimal.Decimal, error) {
+	p.inTx = append(p.inTx, p.db.open)
+	return decimal.RequireFromString("1.1"), nil
+}
+
+// operationStorage serves the operation writes, the any currency limit is matched by the card attribute
+type operationStorage struct {
+	Storage
+	limit      domain.Limit
+	context    domain.Context
+	operations []domain.Operation
+	created    []domain.Operation
+}
+
+func (s *operationStorage) CreateContext(context.Context, domain.Attributes) (uint64, error) {
+	return s.context.ID, nil
+}
+
+func (s *operationStorage) GetContextByID(context.Context, uint64) (domain.Context, error) {
+	return s.context, nil
+}
+
+func (s *operationStorage) GetOperationsByContextID(context.Context, uint64) ([]domain.Operation, error) {
+	return s.operations, nil
+}
+
+func (s *operationStorage) MatchLimits(_ context.Context, _ string, meta domain.Attributes) ([]domain.Limit, error) {
+	for _, attribute := range meta {
+		if attribute.Name == "card" {
+			return []domain.Limit{s.limit}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *operationStorage) CreateOperation(_ context.Context, operation domain.Operation) (domain.Operation, error) {
+	operation.ID = uint64(len(s.created) + 1)
+	s.created = append(s.created, operation)
+
+	return operation, nil
+}
+
+func (s *operationStorage) IncrementCounters(context.Context, uint64, []uint64) ([]domain.ExceededCounters, error) {
+	return nil, nil
+}
+
+func (s *operationStorage) IncrementCountersAndUpdateContext(
+	context.Context,
+	uint64,
+	[]uint64,
+	domain.Context,
+) ([]domain.ExceededCounters, error) {
+	return nil, nil
+}
+
+func newOperationService(t *testing.T, st *operationStorage) (*service, *txRateProvider) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	tp := time_provider.NewTimeProviderMock(t)
+	tp.On("Now").Return(time.Now()).Once()
+
+	db := &txDB{}
+	provider := &txRateProvider{db: db}
+
+	return New(
+		lg,
+		config.StorageTransaction{},
+		db,
+		func(goKitPgx.ExtContext) Storage { return st },
+		tp,
+		WithRateProvider(provider),
+	), provider
+}
+
+func TestService_SendOperation_RatesBeforeTransaction(t *testing.T) {
+	st := &operationStorage{
+		limit: domain.Limit{
+			ID:          1,
+			LimitType:   domain.LimitTypeMAXAMOUNT,
+			Currency:    "USD",
+			AnyCurrency: true,
+			Value:       decimal.NewFromInt(1000),
+		},
+		context: domain.Context{ID: 1},
+	}
+
+	srv, provider := newOperationService(t, st)
+
+	_, err := srv.SendOperation(context.Background(), domain.OperationInfo{
+		Amount: domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(100)},
+		Meta:   domain.Attributes{{Name: "card", Value: "1"}},
+	})
+	require.NoError(t, err)
+
+	// the rate is requested once before the transaction and reused inside it
+	assert.Equal(t, []bool{false}, provider.inTx)
+	require.Len(t, st.created, 1)
+	assert.Equal(t, domain.Rates{"USD": decimal.RequireFromString("1.1")}, st.created[0].Rates)
+}
+
+func TestService_AppendOperation_RatesBeforeTransaction(t *testing.T) {
+	st := &operationStorage{
+		limit: domain.Limit{
+			ID:          1,
+			LimitType:   domain.LimitTypeMAXAMOUNT,
+			Currency:    "USD",
+			AnyCurrency: true,
+			Value:       decimal.NewFromInt(1000),
+		},
+		context: domain.Context{ID: 1, Meta: domain.Attributes{{Name: "user_id", Value: "1"}}},
+		operations: []domain.Operation{{
+			ID:        1,
+			ContextID: 1,
+			Currency:  "EUR",
+			Value:     decimal.NewFromInt(100),
+			Status:    domain.OperationStatusPending,
+		}},
+	}
+
+	srv, provider := newOperationService(t, st)
+
+	_, err := srv.AppendOperation(context.Background(), domain.AppendOperationInfo{
+		ContextID: 1,
+		Meta:      domain.Attributes{{Name: "card", Value: "1"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []bool{false}, provider.inTx)
+	require.Len(t, st.created, 1)
+	assert.Equal(t, domain.Rates{"USD": decimal.RequireFromString("1.1")}, st.created[0].Rates)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/limits/domain"
+)
+
+// getRates returns the rates from the operation currency to the currencies of the matched any currency limits.
+// The known rates are taken as is, the provider is asked for the missing ones only.
+func (s *service) getRates(
+	ctx context.Context,
+	currency string,
+	limits []domain.Limit,
+	known domain.Rates,
+) (domain.Rates, error) {
+	rates := make(domain.Rates)
+
+	for _, limit := range limits {
+		if limit.Currency == currency {
+			continue
+		}
+
+		if _, ok := rates[limit.Currency]; ok {
+			continue
+		}
+
+		if rate, ok := known[limit.Currency]; ok {
+			rates[limit.Currency] = rate
+			continue
+		}
+
+		if s.rateProvider == nil {
+			return nil, ctxerrors.New(ctxerrors.TypeInternal, "rate provider is not set")
+		}
+
+		rate, err := s.rateProvider.Rate(ctx, currency, limit.Currency)
+		if err != nil {
+			s.logger.WithCtx(ctx).
+				WithError(err).
+				With("from", currency).
+				With("to", limit.Currency).
+				Error("get rate failed")
+
+			return nil, err
+		}
+
+		rates[limit.Currency] = rate
+	}
+
+	return rates, nil
+}
+
+// resolveRates gets the rates of the limits matched by the meta before the operation transaction is begun,
+// so the rate provider does not hold its connection and locks. The limits are matched again inside
+// the transaction and getRates completes the rates of the limits created in between.
+func (s *service) resolveRates(ctx context.Context, currency string, meta domain.Attributes) (domain.Rates, error) {
+	if s.rateProvider == nil {
+		return nil, nil
+	}
+
+	limits, err := s.createStorage(s.db).MatchLimits(ctx, currency, meta)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("match limits failed")
+
+		return nil, err
+	}
+
+	return s.getRates(ctx, currency, limits, nil)
+}
+
+// resolveAppendRates is resolveRates of the context meta merged with the appended one
+func (s *service) resolveAppendRates(ctx context.Context, info domain.AppendOperationInfo) (domain.Rates, error) {
+	if s.rateProvider == nil {
+		return nil, nil
+	}
+
+	st := s.createStorage(s.db)
+
+	domainContext, err := st.GetContextByID(ctx, info.ContextID)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get context failed")
+
+		return nil, err
+	}
+
+	meta, err := mergeEntities(domainContext.Meta, info.Meta)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("merge context meta failed")
+
+		return nil, err
+	}
+
+	operations, err := st.GetOperationsByContextID(ctx, info.ContextID)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get operations failed")
+
+		return nil, err
+	}
+
+	operation, err := validateExistedOperations(operations)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			With("context_id", info.ContextID).
+			Error("validate existed operations failed")
+
+		return nil, err
+	}
+
+	return s.resolveRates(ctx, operation.Currency, meta)
+}
+
+// convertAmount converts the operation amount to the limit currency like the counters queries do
+func convertAmount(limit domain.Limit, amount domain.Amount, rates domain.Rates) decimal.Decimal {
+	if limit.Currency == amount.Currency {
+		return amount.Value
+	}
+
+	return amount.Value.Mul(rates[limit.Currency])
+}
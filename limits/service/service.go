@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/underbek/examples-go/limits/config"
 	"github.com/underbek/examples-go/limits/domain"
 	"github.com/underbek/examples-go/logger"
@@ -12,7 +13,7 @@ import (
 
 type Storage interface {
 	CreateLimit(context.Context, domain.Limit) (domain.Limit, error)
-	DeleteLimits(context.Context, []uint64, []uint64) ([]uint64, error)
+	DeleteLimits(context.Context, []uint64) ([]uint64, error)
 	DeleteCounters(context.Context, []uint64) error
 	GetLimitByID(context.Context, uint64) (domain.Limit, error)
 	UpdateLimitValue(context.Context, domain.Limit) (domain.Limit, error)
@@ -28,6 +29,9 @@ type Storage interface {
 	LinkCountersToOperation(ctx context.Context, counterIDs []uint64, operationID uint64) error
 	IncrementCounters(context.Context, uint64, []uint64) ([]domain.ExceededCounters, error)
 	IncrementCountersAndUpdateContext(context.Context, uint64, []uint64, domain.Context) ([]domain.ExceededCounters, error)
+	GetCountersValues(context.Context, []domain.Counter, time.Time) ([]domain.CounterValue, error)
+	MatchUsageLimits(context.Context, domain.UsageFilter) ([]domain.Limit, error)
+	GetCountersUsage(context.Context, []domain.Counter, time.Time) ([]domain.CounterUsage, error)
 
 	CommitOperations(context.Context, []uint64) error
 	RollbackOperations(context.Context, []uint64) ([]domain.ExceededCounters, error)
@@ -37,6 +41,11 @@ type timeProvider interface {
 	Now() time.Time
 }
 
+// rateProvider returns the rate to convert the amount in the currency from to the currency to
+type rateProvider interface {
+	Rate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
+
 type createStorage = func(ext goKitPgx.ExtContext) Storage
 
 type service struct {
@@ -45,6 +54,17 @@ type service struct {
 	db            goKitPgx.Storage
 	createStorage createStorage
 	timeProvider  timeProvider
+	rateProvider  rateProvider
+}
+
+type Option func(*service)
+
+// WithRateProvider Defines the provider of the rates for the any currency limits.
+// Without it the operation matched the any currency limit in another currency fails.
+func WithRateProvider(provider rateProvider) Option {
+	return func(s *service) {
+		s.rateProvider = provider
+	}
 }
 
 func New(
@@ -53,12 +73,19 @@ func New(
 	db goKitPgx.Storage,
 	createStorage createStorage,
 	timeProvider timeProvider,
+	opts ...Option,
 ) *service {
-	return &service{
+	s := &service{
 		logger:        logger,
 		storageTrxCfg: storageTrxCfg,
 		db:            db,
 		createStorage: createStorage,
 		timeProvider:  timeProvider,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"github.com/underbek/examples-go/limits/domain"
+)
+
+// GetUsage returns the usage of the total limits applied to the operations with the filter entities
+// within their current periods. The counters aren't created, the limit without the counter has zero usage.
+func (s *service) GetUsage(ctx context.Context, filter domain.UsageFilter) ([]domain.LimitUsage, error) {
+	err := validateEntities(filter.Entities)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("validate entities failed")
+
+		return nil, err
+	}
+
+	now := s.timeProvider.Now()
+	st := s.createStorage(s.db)
+
+	limits, err := st.MatchUsageLimits(ctx, filter)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("match usage limits failed")
+
+		return nil, err
+	}
+
+	if len(limits) == 0 {
+		return []domain.LimitUsage{}, nil
+	}
+
+	counters, err := s.GenerateCounters(ctx, limits, now)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("generate counters failed")
+
+		return nil, err
+	}
+
+	usages, err := st.GetCountersUsage(ctx, counters, now)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("get counters usage failed")
+
+		return nil, err
+	}
+
+	usagesByLimit := make(map[uint64]domain.CounterUsage, len(usages))
+	for _, usage := range usages {
+		usagesByLimit[usage.LimitID] = usage
+	}
+
+	result := make([]domain.LimitUsage, 0, len(limits))
+	for i, limit := range limits {
+		usage := usagesByLimit[limit.ID]
+		value := domain.CounterValue{
+			LimitID:   limit.ID,
+			CounterID: usage.CounterID,
+			Value:     usage.Value,
+		}
+
+		result = append(result, domain.LimitUsage{
+			CounterHeadroom: newCounterHeadroom(limit, counters[i], value, now),
+			Currency:        limit.Currency,
+			Pending:         usage.Pending,
+			Committed:       usage.Committed,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/limits/config"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/limits/time_provider"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/utils"
+)
+
+// usageStorage serves the read queries of GetUsage, any other call panics on the nil Storage
+type usageStorage struct {
+	Storage
+	limits []domain.Limit
+	usages []domain.CounterUsage
+}
+
+func (s *usageStorage) MatchUsageLimits(context.Context, domain.UsageFilter) ([]domain.Limit, error) {
+	return s.limits, nil
+}
+
+func (s *usageStorage) GetCountersUsage(context.Context, []domain.Counter, time.Time) ([]domain.CounterUsage, error) {
+	return s.usages, nil
+}
+
+func TestService_GetUsage(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 5, 13, 15, 39, 0, 0, time.UTC)
+	tp := time_provider.NewTimeProviderMock(t)
+	tp.On("Now").Return(now).Once()
+
+	st := &usageStorage{
+		limits: []domain.Limit{
+			{
+				ID:        1,
+				LimitType: domain.LimitTypeTOTALAMOUNT,
+				Currency:  "EUR",
+				Value:     decimal.NewFromInt(1000),
+				Period:    utils.ToPtr(domain.PeriodTypeCALENDARMONTH),
+				Timezone:  utils.ToPtr("UTC"),
+			},
+			{
+				ID:        2,
+				LimitType: domain.LimitTypeTOTALCOUNT,
+				Currency:  "EUR",
+				Value:     decimal.NewFromInt(5),
+				Period:    utils.ToPtr(domain.PeriodTypeCALENDARDAY),
+				Timezone:  utils.ToPtr("UTC"),
+			},
+		},
+		usages: []domain.CounterUsage{
+			{
+				LimitID:   1,
+				CounterID: utils.ToPtr(uint64(10)),
+				Value:     decimal.NewFromInt(1200),
+				Pending:   decimal.NewFromInt(200),
+				Committed: decimal.NewFromInt(1000),
+			},
+			{LimitID: 2},
+		},
+	}
+
+	srv := New(lg, config.StorageTransaction{}, nil, func(goKitPgx.ExtContext) Storage { return st }, tp)
+
+	result, err := srv.GetUsage(context.Background(), domain.UsageFilter{
+		Entities: domain.Attributes{{Name: "user_id", Value: "1"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	monthly := result[0]
+	assert.Equal(t, "EUR", monthly.Currency)
+	assert.Equal(t, utils.ToPtr(uint64(10)), monthly.CounterID)
+	assert.True(t, decimal.Zero.Equal(monthly.Remaining))
+	assert.True(t, decimal.NewFromInt(200).Equal(monthly.Pending))
+	assert.True(t, decimal.NewFromInt(1000).Equal(monthly.Committed))
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), monthly.StartTime)
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), monthly.EndTime)
+
+	daily := result[1]
+	assert.Nil(t, daily.CounterID)
+	assert.True(t, decimal.NewFromInt(5).Equal(daily.Remaining))
+	assert.True(t, decimal.Zero.Equal(daily.Pending))
+}
+
+func TestService_GetUsage_EmptyEntities(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	srv := New(lg, config.StorageTransaction{}, nil, nil, time_provider.NewTimeProviderMock(t))
+
+	_, err = srv.GetUsage(context.Background(), domain.UsageFilter{})
+	require.ErrorContains(t, err, "entities is empty")
+}
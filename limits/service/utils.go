@@ -75,10 +75,18 @@ func (s *service) transaction(ctx context.Context, opts *pgx.TxOptions, f func(g
 func generateLimitHash(limit domain.Limit) string {
 	value := fmt.Sprintf("%s:%s", limit.LimitType, limit.Currency)
 
+	if limit.AnyCurrency {
+		value = fmt.Sprintf("%s:any", value)
+	}
+
 	if limit.Period != nil {
 		value = fmt.Sprintf("%s:%s", value, limit.Period)
 	}
 
+	if limit.PeriodLength != nil {
+		value = fmt.Sprintf("%s:%d", value, *limit.PeriodLength)
+	}
+
 	for _, entity := range limit.Entities {
 		value = fmt.Sprintf("%s:%s:%s", value, entity.Name, entity.Value)
 	}
@@ -123,6 +131,46 @@ func generateStartEndPeriods(limit domain.Limit, now time.Time) (time.Time, time
 		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, lc).UTC()
 		end := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, lc).UTC()
 		return start, end, nil
+
+	case domain.PeriodTypeCALENDARQUARTER:
+		month := now.Month() - (now.Month()-1)%3
+		start := time.Date(now.Year(), month, 1, 0, 0, 0, 0, lc).UTC()
+		end := time.Date(now.Year(), month+3, 1, 0, 0, 0, 0, lc).UTC()
+		return start, end, nil
+
+	case domain.PeriodTypeCALENDARYEAR:
+		start := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, lc).UTC()
+		end := time.Date(now.Year()+1, time.January, 1, 0, 0, 0, 0, lc).UTC()
+		return start, end, nil
+
+	case domain.PeriodTypeHOURS:
+		if limit.PeriodLength == nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("period length is nil for limit %d", limit.ID)
+		}
+
+		// the buckets are counted from the midnight, the last one is cut by the next day on DST changes
+		length := time.Duration(*limit.PeriodLength) * time.Hour
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, lc)
+		nextDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, lc)
+
+		start := day.Add(now.Sub(day) / length * length)
+		end := start.Add(length)
+		if end.After(nextDay) {
+			end = nextDay
+		}
+
+		return start.UTC(), end.UTC(), nil
+
+	case domain.PeriodTypeROLLING:
+		if limit.PeriodLength == nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("period length is nil for limit %d", limit.ID)
+		}
+
+		// the rolling limit is checked by the operations within the window, the counter only groups them.
+		// The counter of the window bucket is kept for one more window to be seen by the next operations.
+		length := time.Duration(*limit.PeriodLength) * time.Hour
+		start := now.UTC().Truncate(length)
+		return start, start.Add(2 * length), nil
 	}
 
 	return time.Time{}, time.Time{}, fmt.Errorf("unknown period type %s for limit %d", *limit.Period, limit.ID)
@@ -135,6 +183,14 @@ func GenerateCounterHash(limit domain.Limit, start time.Time) (string, error) {
 
 	value := fmt.Sprintf("%s:%s:%s:%s", limit.LimitType, limit.Currency, limit.Period, start)
 
+	if limit.AnyCurrency {
+		value = fmt.Sprintf("%s:any", value)
+	}
+
+	if limit.PeriodLength != nil {
+		value = fmt.Sprintf("%s:%d", value, *limit.PeriodLength)
+	}
+
 	for _, entity := range limit.Entities {
 		value = fmt.Sprintf("%s:%s:%s", value, entity.Name, entity.Value)
 	}
@@ -142,8 +198,8 @@ func GenerateCounterHash(limit domain.Limit, start time.Time) (string, error) {
 	return base64.StdEncoding.EncodeToString([]byte(value)), nil
 }
 
-func (s *service) deleteLimits(ctx context.Context, st Storage, ids []uint64, scopeIDs []uint64) error {
-	scopedIDs, err := st.DeleteLimits(ctx, ids, scopeIDs)
+func (s *service) deleteLimits(ctx context.Context, st Storage, ids []uint64) error {
+	deletedIDs, err := st.DeleteLimits(ctx, ids)
 	if err != nil {
 		s.logger.WithCtx(ctx).
 			WithError(err).
@@ -153,7 +209,7 @@ func (s *service) deleteLimits(ctx context.Context, st Storage, ids []uint64, sc
 		return err
 	}
 
-	err = st.DeleteCounters(ctx, scopedIDs)
+	err = st.DeleteCounters(ctx, deletedIDs)
 	if err != nil {
 		s.logger.WithCtx(ctx).
 			WithError(err).
@@ -184,23 +240,31 @@ func splitLimits(limits []domain.Limit) ([]domain.Limit, []domain.Limit) {
 	return static, periodic
 }
 
-func checkStaticLimits(limits []domain.Limit, amount decimal.Decimal) error {
+func checkStaticLimits(limits []domain.Limit, amount domain.Amount, rates domain.Rates) error {
 	for _, limit := range limits {
-		switch limit.LimitType {
-		case domain.LimitTypeMINAMOUNT:
-			if amount.LessThan(limit.Value) {
-				return ctxerrors.New(
-					ctxerrors.TypeInternal,
-					fmt.Sprintf("operation amount %s is less than min_limit value %s", amount, limit.Value),
-				)
-			}
-		case domain.LimitTypeMAXAMOUNT:
-			if amount.GreaterThan(limit.Value) {
-				return ctxerrors.New(
-					ctxerrors.TypeInternal,
-					fmt.Sprintf("operation amount %s is greater than max_limit value %s", amount, limit.Value),
-				)
-			}
+		if err := checkStaticLimit(limit, convertAmount(limit, amount, rates)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkStaticLimit(limit domain.Limit, amount decimal.Decimal) error {
+	switch limit.LimitType {
+	case domain.LimitTypeMINAMOUNT:
+		if amount.LessThan(limit.Value) {
+			return ctxerrors.New(
+				ctxerrors.TypeInternal,
+				fmt.Sprintf("operation amount %s is less than min_limit value %s", amount, limit.Value),
+			)
+		}
+	case domain.LimitTypeMAXAMOUNT:
+		if amount.GreaterThan(limit.Value) {
+			return ctxerrors.New(
+				ctxerrors.TypeInternal,
+				fmt.Sprintf("operation amount %s is greater than max_limit value %s", amount, limit.Value),
+			)
 		}
 	}
 
@@ -129,6 +129,90 @@ func TestGenerateStartEndPeriod(t *testing.T) {
 			startPeriod: time.Date(2023, 1, 31, 21, 0, 0, 0, time.UTC),
 			endPeriod:   time.Date(2023, 2, 28, 21, 0, 0, 0, time.UTC),
 		},
+		{
+			name:        "quarterly Moscow",
+			currentTime: time.Date(2023, 5, 13, 15, 39, 0, 0, lc),
+			limit: domain.Limit{
+				Period:   utils.ToPtr(domain.PeriodTypeCALENDARQUARTER),
+				Timezone: utils.ToPtr("Europe/Moscow"),
+			},
+			startPeriod: time.Date(2023, 3, 31, 21, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2023, 6, 30, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "quarterly UTC over year",
+			currentTime: time.Date(2023, 12, 31, 15, 39, 0, 0, time.UTC),
+			limit: domain.Limit{
+				Period:   utils.ToPtr(domain.PeriodTypeCALENDARQUARTER),
+				Timezone: utils.ToPtr("UTC"),
+			},
+			startPeriod: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "yearly Moscow",
+			currentTime: time.Date(2023, 1, 1, 1, 39, 0, 0, lc),
+			limit: domain.Limit{
+				Period:   utils.ToPtr(domain.PeriodTypeCALENDARYEAR),
+				Timezone: utils.ToPtr("Europe/Moscow"),
+			},
+			startPeriod: time.Date(2022, 12, 31, 21, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2023, 12, 31, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "hours without a period length",
+			currentTime: time.Date(2023, 5, 13, 15, 39, 0, 0, lc),
+			limit: domain.Limit{
+				ID:       11,
+				Period:   utils.ToPtr(domain.PeriodTypeHOURS),
+				Timezone: utils.ToPtr("UTC"),
+			},
+			err: "period length is nil for limit 11",
+		},
+		{
+			name:        "6 hours Moscow",
+			currentTime: time.Date(2023, 5, 13, 15, 39, 0, 0, lc),
+			limit: domain.Limit{
+				Period:       utils.ToPtr(domain.PeriodTypeHOURS),
+				PeriodLength: utils.ToPtr(uint32(6)),
+				Timezone:     utils.ToPtr("Europe/Moscow"),
+			},
+			startPeriod: time.Date(2023, 5, 13, 9, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2023, 5, 13, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "8 hours London on DST change",
+			currentTime: time.Date(2023, 3, 26, 20, 0, 0, 0, time.UTC),
+			limit: domain.Limit{
+				Period:       utils.ToPtr(domain.PeriodTypeHOURS),
+				PeriodLength: utils.ToPtr(uint32(8)),
+				Timezone:     utils.ToPtr("Europe/London"),
+			},
+			startPeriod: time.Date(2023, 3, 26, 16, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2023, 3, 26, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "8 hours London on DST end",
+			currentTime: time.Date(2023, 10, 29, 23, 30, 0, 0, time.UTC),
+			limit: domain.Limit{
+				Period:       utils.ToPtr(domain.PeriodTypeHOURS),
+				PeriodLength: utils.ToPtr(uint32(8)),
+				Timezone:     utils.ToPtr("Europe/London"),
+			},
+			startPeriod: time.Date(2023, 10, 29, 23, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2023, 10, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "rolling 24 hours",
+			currentTime: time.Date(2023, 5, 13, 15, 39, 0, 0, lc),
+			limit: domain.Limit{
+				Period:       utils.ToPtr(domain.PeriodTypeROLLING),
+				PeriodLength: utils.ToPtr(uint32(24)),
+				Timezone:     utils.ToPtr("Europe/Moscow"),
+			},
+			startPeriod: time.Date(2023, 5, 13, 0, 0, 0, 0, time.UTC),
+			endPeriod:   time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,3 +229,25 @@ func TestGenerateStartEndPeriod(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateCounterHash_AnyCurrency(t *testing.T) {
+	limit := domain.Limit{
+		LimitType: domain.LimitTypeTOTALAMOUNT,
+		Currency:  "USD",
+		Entities:  domain.Attributes{{Name: "user_id", Value: "1"}},
+		Period:    utils.ToPtr(domain.PeriodTypeCALENDARDAY),
+	}
+	anyCurrency := limit
+	anyCurrency.AnyCurrency = true
+
+	start := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+
+	hash, err := GenerateCounterHash(limit, start)
+	require.NoError(t, err)
+
+	anyHash, err := GenerateCounterHash(anyCurrency, start)
+	require.NoError(t, err)
+
+	require.NotEqual(t, hash, anyHash)
+	require.NotEqual(t, generateLimitHash(limit), generateLimitHash(anyCurrency))
+}
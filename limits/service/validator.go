@@ -11,7 +11,13 @@ import (
 	"github.com/underbek/examples-go/utils"
 )
 
-const defaultTimeZone = "UTC"
+const (
+	defaultTimeZone = "UTC"
+
+	hoursInDay = 24
+	// maxRollingHours bounds the window of the rolling limits by a year
+	maxRollingHours = 366 * hoursInDay
+)
 
 func validateEntities(entities []domain.Attribute) error {
 	const entitiesName = "entities"
@@ -95,6 +101,13 @@ func validateLimit(limit *domain.Limit) error {
 				fmt.Sprintf("limit with %s type has a timezone", limit.LimitType),
 			)
 		}
+
+		if limit.PeriodLength != nil {
+			return ctxerrors.New(
+				ctxerrors.TypeInvalidRequest,
+				fmt.Sprintf("limit with %s type has a period length", limit.LimitType),
+			)
+		}
 	case domain.LimitTypeTOTALAMOUNT, domain.LimitTypeTOTALCOUNT:
 		if limit.Period == nil {
 			return ctxerrors.New(
@@ -103,6 +116,11 @@ func validateLimit(limit *domain.Limit) error {
 			)
 		}
 
+		err = validatePeriodLength(*limit.Period, limit.PeriodLength)
+		if err != nil {
+			return err
+		}
+
 		if limit.Timezone == nil {
 			limit.Timezone = utils.ToPtr(defaultTimeZone)
 		}
@@ -131,6 +149,34 @@ func validateLimit(limit *domain.Limit) error {
 	return nil
 }
 
+func validatePeriodLength(period domain.PeriodType, length *uint32) error {
+	switch period {
+	case domain.PeriodTypeHOURS:
+		if length == nil || *length == 0 || *length > hoursInDay || hoursInDay%*length != 0 {
+			return ctxerrors.New(
+				ctxerrors.TypeInvalidRequest,
+				fmt.Sprintf("limit with %s period must have a period length dividing %d hours", period, hoursInDay),
+			)
+		}
+	case domain.PeriodTypeROLLING:
+		if length == nil || *length == 0 || *length > maxRollingHours {
+			return ctxerrors.New(
+				ctxerrors.TypeInvalidRequest,
+				fmt.Sprintf("limit with %s period must have a period length from 1 to %d hours", period, maxRollingHours),
+			)
+		}
+	default:
+		if length != nil {
+			return ctxerrors.New(
+				ctxerrors.TypeInvalidRequest,
+				fmt.Sprintf("limit with %s period has a period length", period),
+			)
+		}
+	}
+
+	return nil
+}
+
 func validateOperationInfo(operation domain.OperationInfo) error {
 	if operation.Amount.Value.LessThanOrEqual(decimal.Zero) {
 		return ctxerrors.New(
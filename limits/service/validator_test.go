@@ -198,6 +198,80 @@ func TestValidateLimit(t *testing.T) {
 				Timezone:  utils.ToPtr("Europe/London"),
 			},
 		},
+		{
+			name: "hours limit without a period length",
+			limit: &domain.Limit{
+				LimitType: domain.LimitTypeTOTALCOUNT,
+				Value:     decimal.NewFromInt(100),
+				Currency:  "EUR",
+				Entities:  []domain.Attribute{{Name: "merchant_id", Value: "1"}},
+				Period:    utils.ToPtr(domain.PeriodTypeHOURS),
+			},
+			isError:    true,
+			errMessage: "limit with hours period must have a period length dividing 24 hours",
+		},
+		{
+			name: "hours limit with a period length not dividing a day",
+			limit: &domain.Limit{
+				LimitType:    domain.LimitTypeTOTALCOUNT,
+				Value:        decimal.NewFromInt(100),
+				Currency:     "EUR",
+				Entities:     []domain.Attribute{{Name: "merchant_id", Value: "1"}},
+				Period:       utils.ToPtr(domain.PeriodTypeHOURS),
+				PeriodLength: utils.ToPtr(uint32(5)),
+			},
+			isError:    true,
+			errMessage: "limit with hours period must have a period length dividing 24 hours",
+		},
+		{
+			name: "rolling limit with a too long period length",
+			limit: &domain.Limit{
+				LimitType:    domain.LimitTypeTOTALAMOUNT,
+				Value:        decimal.NewFromInt(100),
+				Currency:     "EUR",
+				Entities:     []domain.Attribute{{Name: "merchant_id", Value: "1"}},
+				Period:       utils.ToPtr(domain.PeriodTypeROLLING),
+				PeriodLength: utils.ToPtr(uint32(10000)),
+			},
+			isError:    true,
+			errMessage: "limit with rolling period must have a period length from 1 to 8784 hours",
+		},
+		{
+			name: "calendar limit has a period length",
+			limit: &domain.Limit{
+				LimitType:    domain.LimitTypeTOTALAMOUNT,
+				Value:        decimal.NewFromInt(100),
+				Currency:     "EUR",
+				Entities:     []domain.Attribute{{Name: "merchant_id", Value: "1"}},
+				Period:       utils.ToPtr(domain.PeriodTypeCALENDARQUARTER),
+				PeriodLength: utils.ToPtr(uint32(24)),
+			},
+			isError:    true,
+			errMessage: "limit with calendar_quarter period has a period length",
+		},
+		{
+			name: "amount limit has a period length",
+			limit: &domain.Limit{
+				LimitType:    domain.LimitTypeMAXAMOUNT,
+				Value:        decimal.NewFromInt(100),
+				Currency:     "EUR",
+				Entities:     []domain.Attribute{{Name: "merchant_id", Value: "1"}},
+				PeriodLength: utils.ToPtr(uint32(24)),
+			},
+			isError:    true,
+			errMessage: "limit with max_amount type has a period length",
+		},
+		{
+			name: "validated rolling limit",
+			limit: &domain.Limit{
+				LimitType:    domain.LimitTypeTOTALAMOUNT,
+				Value:        decimal.NewFromInt(100),
+				Currency:     "EUR",
+				Entities:     []domain.Attribute{{Name: "merchant_id", Value: "1"}},
+				Period:       utils.ToPtr(domain.PeriodTypeROLLING),
+				PeriodLength: utils.ToPtr(uint32(24)),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/underbek/examples-go/limits/domain"
+)
+
+// GetCountersValues returns the current values of the counters without creating them.
+// The counter which isn't created yet has zero value, the rolling counter has the value of the window before now.
+func (s *Storage) GetCountersValues(ctx context.Context, counters []domain.Counter, now time.Time) ([]domain.CounterValue, error) {
+	limitIDs := make([]uint64, 0, len(counters))
+	hashes := make([]string, 0, len(counters))
+
+	for _, counter := range counters {
+		limitIDs = append(limitIDs, counter.LimitID)
+		hashes = append(hashes, counter.Hash)
+	}
+
+	query := `SELECT l.id AS limit_id,
+       c.id AS counter_id,
+       (CASE
+            WHEN ((l.period = 'rolling') AND (l.limit_type = 'total_count')) THEN w.count::varchar
+            WHEN ((l.period = 'rolling') AND (l.limit_type = 'total_amount')) THEN w.amount::varchar
+            ELSE COALESCE(c.value, '0')
+           END) AS value
+FROM limits l
+         LEFT JOIN counters c ON c.limit_id = l.id AND c.hash = ANY($2) AND c.deleted_at IS NULL
+         CROSS JOIN LATERAL ` + rollingWindowQuery("$3::timestamp") + ` w
+WHERE l.id = ANY($1);`
+
+	rows, err := s.ext.Query(ctx, query, limitIDs, hashes, now.UTC())
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("select query failed")
+
+		return nil, err
+	}
+
+	result, err := pgx.CollectRows[domain.CounterValue](rows, pgx.RowToStructByName[domain.CounterValue])
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("collect rows failed")
+
+		return nil, err
+	}
+
+	return result, nil
+}
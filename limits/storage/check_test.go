@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/utils"
+)
+
+func (s *TestSuite) Test_storage_GetCountersValues() {
+	ctx := context.Background()
+	day := time.Date(2024, 11, 21, 0, 0, 0, 0, time.UTC)
+
+	daily := s.createLimit(totalLimit("values_daily", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY))
+
+	rolling := totalLimit("values_rolling", domain.LimitTypeTOTALCOUNT, "USD", 10, domain.PeriodTypeROLLING)
+	rolling.PeriodLength = utils.ToPtr(uint32(24))
+	rolling = s.createLimit(rolling)
+
+	dailyCounter := counter(daily, day, day.Add(24*time.Hour))
+	dailyIDs := s.createCounters(dailyCounter)
+
+	previous := counter(rolling, day.Add(-24*time.Hour), day.Add(24*time.Hour))
+	current := counter(rolling, day, day.Add(48*time.Hour))
+	rollingIDs := s.createCounters(previous, current)
+
+	s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(40)}, nil, day.Add(time.Hour), dailyIDs)
+	s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, day.Add(-4*time.Hour), rollingIDs[:1])
+	s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, day.Add(10*time.Hour), rollingIDs[1:])
+
+	// the window before 19:00 has both rolling operations, the window before 21:00 only the current bucket one
+	values, err := s.storage.GetCountersValues(ctx, []domain.Counter{dailyCounter, current}, day.Add(19*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(values, 2)
+
+	byLimit := make(map[uint64]domain.CounterValue, len(values))
+	for _, value := range values {
+		byLimit[value.LimitID] = value
+	}
+
+	s.Equal(&dailyIDs[0], byLimit[daily.ID].CounterID)
+	s.True(decimal.NewFromInt(40).Equal(byLimit[daily.ID].Value))
+	s.Equal(&rollingIDs[1], byLimit[rolling.ID].CounterID)
+	s.True(decimal.NewFromInt(2).Equal(byLimit[rolling.ID].Value))
+
+	values, err = s.storage.GetCountersValues(ctx, []domain.Counter{current}, day.Add(21*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(values, 1)
+	s.True(decimal.NewFromInt(1).Equal(values[0].Value))
+
+	// the counter of the next day isn't created yet
+	values, err = s.storage.GetCountersValues(ctx, []domain.Counter{counter(daily, day.Add(24*time.Hour), day.Add(48*time.Hour))}, day.Add(25*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(values, 1)
+	s.Nil(values[0].CounterID)
+	s.True(decimal.Zero.Equal(values[0].Value))
+}
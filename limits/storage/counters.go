@@ -7,6 +7,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
 	"github.com/underbek/examples-go/limits/domain"
 )
 
@@ -91,8 +92,102 @@ func (s *Storage) CreateCountersIfNotExists(ctx context.Context, counters []doma
 	return ids, nil
 }
 
-func (s *Storage) IncrementCounters(ctx context.Context, operationID uint64, counterIDs []uint64) ([]domain.ExceededCounters, error) {
-	query := `WITH counter_info AS (
+// operationAmountQuery converts the value of the operation o to the currency of the limit l
+// by the rate stored on the operation, the operation in the limit currency has no rate.
+// The amount is NULL when the rate is missing, the increments reject such operations and the rollback skips their counters.
+func operationAmountQuery(o string) string {
+	return `(CASE
+                WHEN (` + o + `.currency = l.currency) THEN ` + o + `.value::numeric
+                ELSE ` + o + `.value::numeric * (` + o + `.rates ->> l.currency)::numeric
+               END)`
+}
+
+type missingRate struct {
+	OperationID uint64 `db:"operation_id"`
+	CounterID   uint64 `db:"counter_id"`
+	Currency    string `db:"currency"`
+}
+
+// getMissingRates returns the counters of the amount limits the operations have no rate to the currency of
+func (s *Storage) getMissingRates(ctx context.Context, operationIDs []uint64) ([]missingRate, error) {
+	query := `SELECT o.id AS operation_id, c.id AS counter_id, l.currency AS currency
+FROM operations o
+         JOIN operation_to_counter otc ON otc.operation_id = o.id
+         JOIN counters c ON c.id = otc.counter_id
+         JOIN limits l ON c.limit_id = l.id
+WHERE o.id = ANY($1)
+  AND c.deleted_at IS NULL
+  AND l.limit_type = 'total_amount'
+  AND ` + operationAmountQuery("o") + ` IS NULL;`
+
+	rows, err := s.ext.Query(ctx, query, operationIDs)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("select query failed")
+
+		return nil, err
+	}
+
+	missing, err := pgx.CollectRows[missingRate](rows, pgx.RowToStructByName[missingRate])
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("collect rows failed")
+
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// checkOperationRates fails when the operations have no rate to the currency of the amount limits of their counters
+func (s *Storage) checkOperationRates(ctx context.Context, operationIDs []uint64) error {
+	missing, err := s.getMissingRates(ctx, operationIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		s.logger.WithCtx(ctx).
+			With("operation_id", missing[0].OperationID).
+			With("counter_id", missing[0].CounterID).
+			With("currency", missing[0].Currency).
+			Error("operation rate is missing")
+
+		return ctxerrors.Errorf(
+			ctxerrors.TypeInternal,
+			"operation %d has no rate to %s",
+			missing[0].OperationID,
+			missing[0].Currency,
+		)
+	}
+
+	return nil
+}
+
+// rollingWindowQuery sums the pending and committed operations of the rolling limit l
+// created within the window before the end, it counts nothing for the other limits.
+func rollingWindowQuery(end string) string {
+	return `(
+        SELECT COUNT(wo.id)                        AS count,
+               COALESCE(SUM(` + operationAmountQuery("wo") + `), 0) AS amount
+        FROM counters wc
+                 JOIN operation_to_counter wotc ON wotc.counter_id = wc.id
+                 JOIN operations wo ON wo.id = wotc.operation_id
+        WHERE l.period = 'rolling'
+          AND wc.limit_id = l.id
+          AND wc.deleted_at IS NULL
+          AND wo.status IN ('pending', 'committed')
+          AND wo.created_at > ` + end + ` - make_interval(hours => l.period_length)
+          AND wo.created_at <= ` + end + `
+    )`
+}
+
+// counterInfoQuery calculates the new values of the counters $2 incremented by the operation $1.
+// The counter keeps the value of its bucket, but the rolling counter is checked by check_value:
+// the sum of the pending and committed operations of the limit within the window before the operation.
+var counterInfoQuery = `counter_values AS (
     SELECT c.id         AS counter_id,
            l.id         AS limit_id,
            l.limit_type AS limit_type,
@@ -103,25 +198,42 @@ func (s *Storage) IncrementCounters(ctx context.Context, operationID uint64, cou
            l.meta       AS meta,
            (CASE
                 WHEN ((l.limit_type = 'total_count')) THEN (c.value::bigint + 1)::varchar
-                WHEN ((l.limit_type = 'total_amount')) THEN (c.value::numeric + o.value::numeric)::varchar
-               END)     AS new_value
+                WHEN ((l.limit_type = 'total_amount')) THEN (c.value::numeric + ` + operationAmountQuery("o") + `)::varchar
+               END)     AS new_value,
+           (CASE
+                WHEN ((l.period IS DISTINCT FROM 'rolling')) THEN NULL
+                WHEN ((l.limit_type = 'total_count')) THEN (w.count + 1)::varchar
+                WHEN ((l.limit_type = 'total_amount')) THEN (w.amount + ` + operationAmountQuery("o") + `)::varchar
+               END)     AS window_value
     FROM counters c
              JOIN limits l ON c.limit_id = l.id
              JOIN operations o ON o.id = $1
+             CROSS JOIN LATERAL ` + rollingWindowQuery("o.created_at") + ` w
     WHERE c.id = ANY($2)
 ),
+     counter_info AS (
+         SELECT *, COALESCE(window_value, new_value) AS check_value
+         FROM counter_values
+     )`
+
+func (s *Storage) IncrementCounters(ctx context.Context, operationID uint64, counterIDs []uint64) ([]domain.ExceededCounters, error) {
+	if err := s.checkOperationRates(ctx, []uint64{operationID}); err != nil {
+		return nil, err
+	}
+
+	query := `WITH ` + counterInfoQuery + `,
      updated_counters AS (
          UPDATE counters c
              SET value = CASE
                              WHEN (
-                                 (counter_info.new_value::numeric <= counter_info.limit_value::numeric)
+                                 (counter_info.check_value::numeric <= counter_info.limit_value::numeric)
                                  ) THEN counter_info.new_value
                              ELSE value
                  END,
 				 updated_at = now()
              FROM counter_info
              WHERE c.id = counter_info.counter_id
-             RETURNING counter_id, counter_info.limit_id, limit_type, period, meta, new_value, limit_value
+             RETURNING counter_id, counter_info.limit_id, limit_type, period, meta, check_value AS new_value, limit_value
      ),
      updated_operations AS (
          UPDATE operations o
@@ -160,36 +272,23 @@ func (s *Storage) IncrementCountersAndUpdateContext(
 	counterIDs []uint64,
 	domainContext domain.Context,
 ) ([]domain.ExceededCounters, error) {
-	query := `WITH counter_info AS (
-    SELECT c.id         AS counter_id,
-           l.id         AS limit_id,
-           l.limit_type AS limit_type,
-           l.period     AS period,
-           c.value      AS counter_value,
-           l.value      AS limit_value,
-           o.id         AS operation_id,
-           l.meta       AS meta,
-           (CASE
-                WHEN ((l.limit_type = 'total_count')) THEN (c.value::bigint + 1)::varchar
-                WHEN ((l.limit_type = 'total_amount')) THEN (c.value::numeric + o.value::numeric)::varchar
-               END)     AS new_value
-    FROM counters c
-             JOIN limits l ON c.limit_id = l.id
-             JOIN operations o ON o.id = $1
-    WHERE c.id = ANY($2)
-),
+	if err := s.checkOperationRates(ctx, []uint64{operationID}); err != nil {
+		return nil, err
+	}
+
+	query := `WITH ` + counterInfoQuery + `,
      updated_counters AS (
          UPDATE counters c
              SET value = CASE
                              WHEN (
-                                 (counter_info.new_value::numeric <= counter_info.limit_value::numeric)
+                                 (counter_info.check_value::numeric <= counter_info.limit_value::numeric)
                                  ) THEN counter_info.new_value
                              ELSE value
                  END,
 				 updated_at = now()
              FROM counter_info
              WHERE c.id = counter_info.counter_id
-             RETURNING counter_id, counter_info.limit_id, limit_type, period, meta, new_value, limit_value
+             RETURNING counter_id, counter_info.limit_id, limit_type, period, meta, check_value AS new_value, limit_value
      ),
      updated_operations AS (
          UPDATE operations o
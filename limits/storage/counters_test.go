@@ -3,6 +3,10 @@ package storage
 import (
 	"context"
 	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/utils"
 )
 
 func (s *TestSuite) Test_storage_CleanupCounters() {
@@ -43,3 +47,89 @@ func (s *TestSuite) Test_storage_CleanupCounters() {
 		})
 	}
 }
+
+func (s *TestSuite) Test_storage_IncrementCounters_Rejected() {
+	start := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+	limit := s.createLimit(totalLimit("rejected", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY))
+	ids := s.createCounters(counter(limit, start, start.Add(24*time.Hour)))
+
+	_, exceeded := s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(80)}, nil, start.Add(time.Hour), ids)
+	s.Empty(exceeded)
+	s.True(decimal.NewFromInt(80).Equal(s.counterValue(ids[0])))
+
+	rejectedID, exceeded := s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(30)}, nil, start.Add(2*time.Hour), ids)
+	s.Require().Len(exceeded, 1)
+	s.Equal(limit.ID, exceeded[0].LimitID)
+	s.True(decimal.NewFromInt(110).Equal(exceeded[0].NewValue))
+
+	// the rejected operation changes nothing
+	s.True(decimal.NewFromInt(80).Equal(s.counterValue(ids[0])))
+	s.Equal(domain.OperationStatusNew, s.operationStatus(rejectedID))
+
+	_, exceeded = s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(20)}, nil, start.Add(3*time.Hour), ids)
+	s.Empty(exceeded)
+	s.True(decimal.NewFromInt(100).Equal(s.counterValue(ids[0])))
+}
+
+func (s *TestSuite) Test_storage_IncrementCounters_RollingWindow() {
+	limit := totalLimit("rolling", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeROLLING)
+	limit.PeriodLength = utils.ToPtr(uint32(24))
+	limit = s.createLimit(limit)
+
+	// the buckets of the rolling counters are kept for two windows
+	first := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	firstIDs := s.createCounters(counter(limit, first, first.Add(48*time.Hour)))
+	secondIDs := s.createCounters(counter(limit, second, second.Add(48*time.Hour)))
+
+	_, exceeded := s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(60)}, nil, first.Add(20*time.Hour), firstIDs)
+	s.Empty(exceeded)
+
+	// the window of the next bucket operation still has the previous bucket one
+	rejectedID, exceeded := s.sendOperation(
+		domain.Amount{Currency: "USD", Value: decimal.NewFromInt(50)},
+		nil,
+		second.Add(10*time.Hour),
+		secondIDs,
+	)
+	s.Require().Len(exceeded, 1)
+	s.True(decimal.NewFromInt(110).Equal(exceeded[0].NewValue))
+	s.True(decimal.Zero.Equal(s.counterValue(secondIDs[0])))
+	s.Equal(domain.OperationStatusNew, s.operationStatus(rejectedID))
+
+	// the previous bucket operation is out of the window later
+	_, exceeded = s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(50)}, nil, second.Add(21*time.Hour), secondIDs)
+	s.Empty(exceeded)
+
+	s.True(decimal.NewFromInt(60).Equal(s.counterValue(firstIDs[0])))
+	s.True(decimal.NewFromInt(50).Equal(s.counterValue(secondIDs[0])))
+}
+
+func (s *TestSuite) Test_storage_IncrementCounters_HoursOnDST() {
+	limit := totalLimit("hours_dst", domain.LimitTypeTOTALCOUNT, "USD", 1, domain.PeriodTypeHOURS)
+	limit.PeriodLength = utils.ToPtr(uint32(8))
+	limit.Timezone = utils.ToPtr("Europe/London")
+	limit = s.createLimit(limit)
+
+	// London moves to BST on 2023-03-26, the last 8 hours bucket of the day is cut by the midnight to 7 hours
+	lastStart := time.Date(2023, 3, 26, 16, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2023, 3, 26, 23, 0, 0, 0, time.UTC)
+	ids := s.createCounters(
+		counter(limit, lastStart, nextDay),
+		counter(limit, nextDay, nextDay.Add(8*time.Hour)),
+	)
+	s.NotEqual(ids[0], ids[1])
+
+	_, exceeded := s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, nextDay.Add(-time.Minute), ids[:1])
+	s.Empty(exceeded)
+
+	_, exceeded = s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, nextDay.Add(-time.Minute), ids[:1])
+	s.Len(exceeded, 1)
+
+	// the first bucket of the next day is counted from zero right after the cut one
+	_, exceeded = s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, nextDay.Add(time.Minute), ids[1:])
+	s.Empty(exceeded)
+
+	s.True(decimal.NewFromInt(1).Equal(s.counterValue(ids[0])))
+	s.True(decimal.NewFromInt(1).Equal(s.counterValue(ids[1])))
+}
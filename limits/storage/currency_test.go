@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/limits/domain"
+)
+
+func (s *TestSuite) Test_storage_AnyCurrencyCounters() {
+	ctx := context.Background()
+	start := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	usd := s.createLimit(totalLimit("any_currency", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY))
+
+	anyUSD := totalLimit("any_currency", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY)
+	anyUSD.Hash = "any_currency:any"
+	anyUSD.AnyCurrency = true
+	anyUSD = s.createLimit(anyUSD)
+
+	// both limits are matched by the USD operation and each one has its own counter
+	limits, err := s.storage.MatchLimits(ctx, "USD", usd.Entities)
+	s.Require().NoError(err)
+	s.Len(limits, 2)
+
+	ids := s.createCounters(counter(usd, start, end), counter(anyUSD, start, end))
+	s.NotEqual(ids[0], ids[1])
+
+	// the EUR operation is matched and counted by the any currency limit only
+	limits, err = s.storage.MatchLimits(ctx, "EUR", usd.Entities)
+	s.Require().NoError(err)
+	s.Require().Len(limits, 1)
+	s.Equal(anyUSD.ID, limits[0].ID)
+
+	_, exceeded := s.sendOperation(
+		domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(10)},
+		domain.Rates{"USD": decimal.RequireFromString("1.1")},
+		start.Add(time.Hour),
+		ids[1:],
+	)
+	s.Empty(exceeded)
+
+	s.True(decimal.Zero.Equal(s.counterValue(ids[0])))
+	s.True(decimal.NewFromInt(11).Equal(s.counterValue(ids[1])))
+}
+
+func (s *TestSuite) Test_storage_AnyCurrencyRollback() {
+	ctx := context.Background()
+	start := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+
+	limit := totalLimit("any_currency_rollback", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY)
+	limit.AnyCurrency = true
+	limit = s.createLimit(limit)
+
+	ids := s.createCounters(counter(limit, start, start.Add(24*time.Hour)))
+
+	operationID, exceeded := s.sendOperation(
+		domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(10)},
+		domain.Rates{"USD": decimal.RequireFromString("1.1")},
+		start.Add(time.Hour),
+		ids,
+	)
+	s.Require().Empty(exceeded)
+	s.True(decimal.NewFromInt(11).Equal(s.counterValue(ids[0])))
+
+	// the rollback subtracts the converted amount, not the operation value
+	exceeded, err := s.storage.RollbackOperations(ctx, []uint64{operationID})
+	s.Require().NoError(err)
+	s.Empty(exceeded)
+	s.True(decimal.Zero.Equal(s.counterValue(ids[0])))
+	s.Equal(domain.OperationStatusRollback, s.operationStatus(operationID))
+}
+
+func (s *TestSuite) Test_storage_AnyCurrencyMissingRate() {
+	ctx := context.Background()
+	start := time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)
+
+	amountLimit := totalLimit("any_currency_missing_rate", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY)
+	amountLimit.AnyCurrency = true
+	amountLimit = s.createLimit(amountLimit)
+
+	countLimit := s.createLimit(totalLimit("any_currency_missing_rate_count", domain.LimitTypeTOTALCOUNT, "EUR", 10, domain.PeriodTypeCALENDARDAY))
+
+	ids := s.createCounters(
+		counter(amountLimit, start, start.Add(24*time.Hour)),
+		counter(countLimit, start, start.Add(24*time.Hour)),
+	)
+
+	operationID := s.createOperation(
+		domain.Amount{Currency: "EUR", Value: decimal.NewFromInt(10)},
+		domain.Rates{"GBP": decimal.RequireFromString("0.8")},
+		start.Add(time.Hour),
+		ids,
+	)
+
+	_, err := s.storage.IncrementCounters(ctx, operationID, ids)
+	s.Require().Error(err)
+	s.Equal(ctxerrors.TypeInternal, ctxerrors.ErrorType(err))
+
+	s.True(decimal.Zero.Equal(s.counterValue(ids[0])))
+	s.True(decimal.Zero.Equal(s.counterValue(ids[1])))
+	s.Equal(domain.OperationStatusNew, s.operationStatus(operationID))
+
+	// the counters counted before the rate is lost
+	_, err = s.db.Exec(ctx, "UPDATE counters SET value = 5 WHERE id = $1", ids[0])
+	s.Require().NoError(err)
+	_, err = s.db.Exec(ctx, "UPDATE counters SET value = 1 WHERE id = $1", ids[1])
+	s.Require().NoError(err)
+
+	// the rollback skips the unconvertible counter and finalizes the operation
+	exceeded, err := s.storage.RollbackOperations(ctx, []uint64{operationID})
+	s.Require().NoError(err)
+	s.Empty(exceeded)
+
+	s.True(decimal.NewFromInt(5).Equal(s.counterValue(ids[0])))
+	s.True(decimal.Zero.Equal(s.counterValue(ids[1])))
+	s.Equal(domain.OperationStatusRollback, s.operationStatus(operationID))
+}
@@ -39,6 +39,20 @@ func (s *Storage) CommitOperations(ctx context.Context, ids []uint64) error {
 }
 
 func (s *Storage) RollbackOperations(ctx context.Context, ids []uint64) ([]domain.ExceededCounters, error) {
+	// the operation is finalized anyway, the counter not convertible to its limit currency is left as is
+	missing, err := s.getMissingRates(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rate := range missing {
+		s.logger.WithCtx(ctx).
+			With("operation_id", rate.OperationID).
+			With("counter_id", rate.CounterID).
+			With("currency", rate.Currency).
+			Error("skip counter rollback, operation rate is missing")
+	}
+
 	query := `WITH counter_info AS (
     SELECT c.id         AS counter_id,
            l.id         AS limit_id,
@@ -50,7 +64,7 @@ func (s *Storage) RollbackOperations(ctx context.Context, ids []uint64) ([]domai
            l.meta       AS meta,
            (CASE
                 WHEN ((l.limit_type = 'total_count')) THEN (c.value::bigint - 1)::varchar
-                WHEN ((l.limit_type = 'total_amount')) THEN (c.value::numeric - o.value::numeric)::varchar
+                WHEN ((l.limit_type = 'total_amount')) THEN (c.value::numeric - ` + operationAmountQuery("o") + `)::varchar
                END)     AS new_value
 	FROM operations o
 			JOIN operation_to_counter otc ON otc.operation_id = o.id
@@ -58,6 +72,7 @@ func (s *Storage) RollbackOperations(ctx context.Context, ids []uint64) ([]domai
 			JOIN limits l ON c.limit_id = l.id
     WHERE o.id = ANY($1)
 	AND c.deleted_at IS NULL
+	AND (l.limit_type <> 'total_amount' OR ` + operationAmountQuery("o") + ` IS NOT NULL)
 ),
      updated_counters AS (
          UPDATE counters c
@@ -8,7 +8,7 @@ import (
 	"github.com/underbek/examples-go/limits/domain"
 )
 
-func (s *Storage) GetLimits(ctx context.Context, filter domain.LimitsFilter, scope []uint64) ([]domain.Limit, uint64, error) {
+func (s *Storage) GetLimits(ctx context.Context, filter domain.LimitsFilter) ([]domain.Limit, uint64, error) {
 	builder, err := s.createLimitsFilterBuilder(filter)
 	if err != nil {
 		s.logger.WithCtx(ctx).
@@ -38,9 +38,11 @@ func (s *Storage) GetLimits(ctx context.Context, filter domain.LimitsFilter, sco
 		"hash",
 		"limit_type",
 		"currency",
+		"any_currency",
 		"value",
 		"meta",
 		"period",
+		"period_length",
 		"timezone",
 		"created_at",
 		"updated_at",
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/utils"
+)
+
+func (s *TestSuite) createLimit(limit domain.Limit) domain.Limit {
+	limit, err := s.storage.CreateLimit(context.Background(), limit)
+	s.Require().NoError(err)
+
+	return limit
+}
+
+func totalLimit(hash string, limitType domain.LimitType, currency string, value int64, period domain.PeriodType) domain.Limit {
+	return domain.Limit{
+		Hash:      hash,
+		LimitType: limitType,
+		Currency:  currency,
+		Value:     decimal.NewFromInt(value),
+		Entities:  domain.Attributes{{Name: "user_id", Value: hash}},
+		Period:    utils.ToPtr(period),
+		Timezone:  utils.ToPtr("UTC"),
+	}
+}
+
+func counter(limit domain.Limit, start, end time.Time) domain.Counter {
+	return domain.Counter{
+		Hash:      fmt.Sprintf("%s:%s", limit.Hash, start.Format(time.RFC3339)),
+		LimitID:   limit.ID,
+		StartTime: start,
+		EndTime:   end,
+	}
+}
+
+func (s *TestSuite) createCounters(counters ...domain.Counter) []uint64 {
+	ids, err := s.storage.CreateCountersIfNotExists(context.Background(), counters)
+	s.Require().NoError(err)
+	s.Require().Len(ids, len(counters))
+
+	return ids
+}
+
+// createOperation creates the operation at createdAt and links it to the counters
+func (s *TestSuite) createOperation(
+	amount domain.Amount,
+	rates domain.Rates,
+	createdAt time.Time,
+	counterIDs []uint64,
+) uint64 {
+	ctx := context.Background()
+
+	ctxID, err := s.storage.CreateContext(ctx, domain.Attributes{{Name: "user_id", Value: "test"}})
+	s.Require().NoError(err)
+
+	operation, err := s.storage.CreateOperation(ctx, domain.Operation{
+		ContextID: ctxID,
+		Currency:  amount.Currency,
+		Value:     amount.Value,
+		Status:    domain.OperationStatusNew,
+		Rates:     rates,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.db.Exec(ctx, "UPDATE operations SET created_at = $1 WHERE id = $2", createdAt.UTC(), operation.ID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.storage.LinkCountersToOperation(ctx, counterIDs, operation.ID))
+
+	return operation.ID
+}
+
+// sendOperation creates the operation and increments the counters.
+// The increment exceeding a limit is rolled back like the service does, the operation stays new.
+func (s *TestSuite) sendOperation(
+	amount domain.Amount,
+	rates domain.Rates,
+	createdAt time.Time,
+	counterIDs []uint64,
+) (uint64, []domain.ExceededCounters) {
+	ctx := context.Background()
+	operationID := s.createOperation(amount, rates, createdAt, counterIDs)
+
+	tx, err := s.db.Begin(ctx, &pgx.TxOptions{IsoLevel: pgx.Serializable})
+	s.Require().NoError(err)
+
+	exceeded, err := New(s.storage.logger, tx, s.storage.maxLimit).IncrementCounters(ctx, operationID, counterIDs)
+	s.Require().NoError(err)
+
+	if len(exceeded) > 0 {
+		s.Require().NoError(tx.Rollback(ctx))
+	} else {
+		s.Require().NoError(tx.Commit(ctx))
+	}
+
+	return operationID, exceeded
+}
+
+func (s *TestSuite) counterValue(id uint64) decimal.Decimal {
+	var value string
+	s.Require().NoError(s.db.QueryRow(context.Background(), "SELECT value FROM counters WHERE id = $1", id).Scan(&value))
+
+	return decimal.RequireFromString(value)
+}
+
+func (s *TestSuite) operationStatus(id uint64) domain.OperationStatus {
+	var status domain.OperationStatus
+	s.Require().NoError(s.db.QueryRow(context.Background(), "SELECT status FROM operations WHERE id = $1", id).Scan(&status))
+
+	return status
+}
@@ -14,15 +14,17 @@ func (s *Storage) MatchLimits(ctx context.Context, currency string, meta domain.
 		"hash",
 		"limit_type",
 		"currency",
+		"any_currency",
 		"value",
 		"meta",
 		"period",
+		"period_length",
 		"timezone",
 		"created_at",
 		"updated_at",
 	).From("limits").
 		Where(sq.Eq{"deleted_at": nil}).
-		Where(sq.Eq{"currency": currency}).
+		Where(sq.Or{sq.Eq{"currency": currency}, sq.Eq{"any_currency": true}}).
 		Where("meta <@ ?", meta).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -18,19 +18,23 @@ func (s *Storage) CreateLimit(ctx context.Context, limit domain.Limit) (domain.L
 		Columns(
 			"hash",
 			"currency",
+			"any_currency",
 			"meta",
 			"limit_type",
 			"value",
 			"period",
+			"period_length",
 			"timezone",
 		).
 		Values(
 			limit.Hash,
 			limit.Currency,
+			limit.AnyCurrency,
 			limit.Entities,
 			limit.LimitType,
 			limit.Value,
 			limit.Period,
+			limit.PeriodLength,
 			limit.Timezone,
 		).
 		Suffix("RETURNING id, created_at, updated_at").
@@ -149,10 +153,12 @@ func (s *Storage) GetLimitByID(ctx context.Context, id uint64) (domain.Limit, er
 		"id",
 		"hash",
 		"currency",
+		"any_currency",
 		"meta",
 		"limit_type",
 		"value",
 		"period",
+		"period_length",
 		"timezone",
 		"created_at",
 		"updated_at",
@@ -16,12 +16,14 @@ func (s *Storage) CreateOperation(ctx context.Context, operation domain.Operatio
 			"value",
 			"currency",
 			"status",
+			"rates",
 		).
 		Values(
 			operation.ContextID,
 			operation.Value,
 			operation.Currency,
 			operation.Status,
+			operation.Rates,
 		).
 		Suffix("RETURNING id, created_at, updated_at").
 		PlaceholderFormat(sq.Dollar).
@@ -73,6 +75,7 @@ func (s *Storage) GetOperationsByContextID(ctx context.Context, ctxID uint64) ([
 		"o.currency",
 		"o.value",
 		"o.status",
+		"o.rates",
 		"o.created_at",
 		"o.updated_at",
 	).
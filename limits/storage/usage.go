@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/underbek/examples-go/limits/domain"
+)
+
+// MatchUsageLimits returns the total limits applied to the operations with the filter entities
+func (s *Storage) MatchUsageLimits(ctx context.Context, filter domain.UsageFilter) ([]domain.Limit, error) {
+	builder := sq.Select(
+		"id",
+		"hash",
+		"limit_type",
+		"currency",
+		"any_currency",
+		"value",
+		"meta",
+		"period",
+		"period_length",
+		"timezone",
+		"created_at",
+		"updated_at",
+	).From("limits").
+		Where(sq.Eq{"deleted_at": nil}).
+		Where(sq.NotEq{"period": nil}).
+		Where("meta <@ ?", filter.Entities)
+
+	if len(filter.LimitTypes) != 0 {
+		builder = builder.Where(sq.Eq{"limit_type": filter.LimitTypes})
+	}
+
+	if filter.Currency != nil {
+		builder = builder.Where(sq.Eq{"currency": filter.Currency})
+	}
+
+	if filter.Period != nil {
+		builder = builder.Where(sq.Eq{"period": filter.Period})
+	}
+
+	query, args, err := builder.
+		OrderBy("id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("create query failed")
+
+		return nil, err
+	}
+
+	rows, err := s.ext.Query(ctx, query, args...)
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("query failed")
+
+		return nil, err
+	}
+
+	limits, err := pgx.CollectRows[domain.Limit](rows, pgx.RowToStructByName[domain.Limit])
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("collect rows failed")
+
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+// GetCountersUsage returns the values of the counters with the totals of their pending and committed operations.
+// The operations are counted in the counter bucket, for the rolling limit within the window before now.
+func (s *Storage) GetCountersUsage(ctx context.Context, counters []domain.Counter, now time.Time) ([]domain.CounterUsage, error) {
+	limitIDs := make([]uint64, 0, len(counters))
+	hashes := make([]string, 0, len(counters))
+
+	for _, counter := range counters {
+		limitIDs = append(limitIDs, counter.LimitID)
+		hashes = append(hashes, counter.Hash)
+	}
+
+	query := `SELECT l.id AS limit_id,
+       c.id AS counter_id,
+       (CASE
+            WHEN ((l.period = 'rolling')) THEN (u.pending + u.committed)::varchar
+            ELSE COALESCE(c.value, '0')
+           END)              AS value,
+       u.pending::varchar   AS pending,
+       u.committed::varchar AS committed
+FROM limits l
+         LEFT JOIN counters c ON c.limit_id = l.id AND c.hash = ANY($2) AND c.deleted_at IS NULL
+         CROSS JOIN LATERAL (
+    SELECT (CASE
+                WHEN ((l.limit_type = 'total_count')) THEN COUNT(uo.id) FILTER (WHERE uo.status = 'pending')
+                ELSE COALESCE(SUM(` + operationAmountQuery("uo") + `) FILTER (WHERE uo.status = 'pending'), 0)
+               END) AS pending,
+           (CASE
+                WHEN ((l.limit_type = 'total_count')) THEN COUNT(uo.id) FILTER (WHERE uo.status = 'committed')
+                ELSE COALESCE(SUM(` + operationAmountQuery("uo") + `) FILTER (WHERE uo.status = 'committed'), 0)
+               END) AS committed
+    FROM counters uc
+             JOIN operation_to_counter uotc ON uotc.counter_id = uc.id
+             JOIN operations uo ON uo.id = uotc.operation_id
+    WHERE uc.limit_id = l.id
+      AND uc.deleted_at IS NULL
+      AND (CASE
+               WHEN ((l.period = 'rolling')) THEN uo.created_at > $3::timestamp - make_interval(hours => l.period_length)
+                   AND uo.created_at <= $3::timestamp
+               ELSE uc.id = c.id
+        END)
+    ) u
+WHERE l.id = ANY($1)
+ORDER BY l.id;`
+
+	rows, err := s.ext.Query(ctx, query, limitIDs, hashes, now.UTC())
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("select query failed")
+
+		return nil, err
+	}
+
+	result, err := pgx.CollectRows[domain.CounterUsage](rows, pgx.RowToStructByName[domain.CounterUsage])
+	if err != nil {
+		s.logger.WithCtx(ctx).
+			WithError(err).
+			Error("collect rows failed")
+
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/underbek/examples-go/limits/domain"
+	"github.com/underbek/examples-go/utils"
+)
+
+func (s *TestSuite) Test_storage_GetCountersUsage() {
+	ctx := context.Background()
+	day := time.Date(2024, 11, 21, 0, 0, 0, 0, time.UTC)
+
+	daily := s.createLimit(totalLimit("usage_daily", domain.LimitTypeTOTALAMOUNT, "USD", 100, domain.PeriodTypeCALENDARDAY))
+
+	rolling := totalLimit("usage_rolling", domain.LimitTypeTOTALCOUNT, "USD", 10, domain.PeriodTypeROLLING)
+	rolling.PeriodLength = utils.ToPtr(uint32(24))
+	rolling = s.createLimit(rolling)
+
+	dailyCounter := counter(daily, day, day.Add(24*time.Hour))
+	dailyIDs := s.createCounters(dailyCounter)
+
+	previous := counter(rolling, day.Add(-24*time.Hour), day.Add(24*time.Hour))
+	current := counter(rolling, day, day.Add(48*time.Hour))
+	rollingIDs := s.createCounters(previous, current)
+
+	first, _ := s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(30)}, nil, day.Add(time.Hour), dailyIDs)
+	s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(15)}, nil, day.Add(2*time.Hour), dailyIDs)
+	old, _ := s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, day.Add(-4*time.Hour), rollingIDs[:1])
+	s.sendOperation(domain.Amount{Currency: "USD", Value: decimal.NewFromInt(1)}, nil, day.Add(10*time.Hour), rollingIDs[1:])
+
+	s.Require().NoError(s.storage.CommitOperations(ctx, []uint64{first, old}))
+
+	usage, err := s.storage.GetCountersUsage(ctx, []domain.Counter{dailyCounter, current}, day.Add(19*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(usage, 2)
+
+	s.Equal(daily.ID, usage[0].LimitID)
+	s.Equal(&dailyIDs[0], usage[0].CounterID)
+	s.True(decimal.NewFromInt(45).Equal(usage[0].Value))
+	s.True(decimal.NewFromInt(15).Equal(usage[0].Pending))
+	s.True(decimal.NewFromInt(30).Equal(usage[0].Committed))
+
+	s.Equal(rolling.ID, usage[1].LimitID)
+	s.Equal(&rollingIDs[1], usage[1].CounterID)
+	s.True(decimal.NewFromInt(2).Equal(usage[1].Value))
+	s.True(decimal.NewFromInt(1).Equal(usage[1].Pending))
+	s.True(decimal.NewFromInt(1).Equal(usage[1].Committed))
+
+	// the committed operation of the previous bucket leaves the window
+	usage, err = s.storage.GetCountersUsage(ctx, []domain.Counter{current}, day.Add(21*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(usage, 1)
+	s.True(decimal.NewFromInt(1).Equal(usage[0].Value))
+	s.True(decimal.NewFromInt(1).Equal(usage[0].Pending))
+	s.True(decimal.Zero.Equal(usage[0].Committed))
+
+	// the counter of the next day isn't created yet
+	usage, err = s.storage.GetCountersUsage(ctx, []domain.Counter{counter(daily, day.Add(24*time.Hour), day.Add(48*time.Hour))}, day.Add(25*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(usage, 1)
+	s.Nil(usage[0].CounterID)
+	s.True(decimal.Zero.Equal(usage[0].Value))
+	s.True(decimal.Zero.Equal(usage[0].Pending))
+	s.True(decimal.Zero.Equal(usage[0].Committed))
+}
@@ -0,0 +1,206 @@
+package pgtaskpool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/tracing"
+)
+
+const defaultListLimit = 100
+
+type (
+	// Admin inspects and manages the stored tasks
+	Admin interface {
+		ListTasks(context.Context, TaskFilter) ([]Task, error)
+		GetTask(context.Context, uint64) (Task, error)
+		// ListAttempts returns the attempts history of the task, see WithAttemptsHistory
+		ListAttempts(context.Context, uint64) ([]Attempt, error)
+		// RequeueTasks schedules the failed tasks matching the filter to run again from the first attempt.
+		// The failed tasks moved to the dead letter table by WithDeadLetterTable are not requeued.
+		RequeueTasks(context.Context, TaskFilter) (int64, error)
+		// CancelTasks cancels the processing tasks matching the filter
+		CancelTasks(context.Context, TaskFilter) (int64, error)
+	}
+
+	// TaskFilter selects the tasks, the empty fields match any task.
+	// Limit and Offset page ListTasks only, the bulk operations affect all the matching tasks
+	// and reject the filter without conditions unless All is set.
+	TaskFilter struct {
+		Status         string
+		Kind           *string
+		TransactionIDs []uint64
+		ErrorCode      *uint64
+		// CreatedFrom and CreatedTo bound the creation time of the tasks, the end is exclusive
+		CreatedFrom *time.Time
+		CreatedTo   *time.Time
+		Limit       uint64
+		Offset      uint64
+		// All confirms the bulk operation of all the tasks by the filter without conditions
+		All bool
+	}
+)
+
+// checkBulk protects the bulk operations from the empty filter affecting the whole table
+func (f TaskFilter) checkBulk() error {
+	empty := f.Kind == nil && len(f.TransactionIDs) == 0 && f.ErrorCode == nil &&
+		f.CreatedFrom == nil && f.CreatedTo == nil
+
+	if empty && !f.All {
+		return ctxerrors.New(ctxerrors.TypeInvalidRequest, "filter is empty, set all to affect all the tasks")
+	}
+
+	return nil
+}
+
+func (f TaskFilter) where() sq.And {
+	where := sq.And{}
+
+	if f.Status != "" {
+		where = append(where, sq.Eq{"status": f.Status})
+	}
+	if f.Kind != nil {
+		where = append(where, sq.Eq{"kind": *f.Kind})
+	}
+	if len(f.TransactionIDs) != 0 {
+		where = append(where, sq.Eq{"transaction_id": f.TransactionIDs})
+	}
+	if f.ErrorCode != nil {
+		where = append(where, sq.Eq{"last_error_code": *f.ErrorCode})
+	}
+	if f.CreatedFrom != nil {
+		where = append(where, sq.GtOrEq{"created_at": *f.CreatedFrom})
+	}
+	if f.CreatedTo != nil {
+		where = append(where, sq.Lt{"created_at": *f.CreatedTo})
+	}
+
+	return where
+}
+
+func (w *worker) ListTasks(ctx context.Context, filter TaskFilter) ([]Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "pgtaskpool", "List tasks")
+	defer span.End()
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultListLimit
+	}
+
+	sql, args, err := sq.Select(taskColumns...).
+		From(w.config.Table).
+		Where(filter.where()).
+		OrderBy("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := w.storage.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to list tasks")
+	}
+
+	tasks, err := pgx.CollectRows(rows, pgx.RowToStructByName[Task])
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect tasks")
+	}
+
+	return tasks, nil
+}
+
+func (w *worker) GetTask(ctx context.Context, id uint64) (Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "pgtaskpool", "Get task")
+	defer span.End()
+
+	sql, args, err := sq.Select(taskColumns...).
+		From(w.config.Table).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return Task{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := w.storage.Query(ctx, sql, args...)
+	if err != nil {
+		return Task{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get task")
+	}
+
+	task, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Task])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Task{}, ctxerrors.Errorf(ctxerrors.TypeNotFound, "task with id %d is not exists", id)
+	}
+	if err != nil {
+		return Task{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect task")
+	}
+
+	return task, nil
+}
+
+func (w *worker) RequeueTasks(ctx context.Context, filter TaskFilter) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "pgtaskpool", "Requeue tasks")
+	defer span.End()
+
+	if err := filter.checkBulk(); err != nil {
+		return 0, err
+	}
+
+	filter.Status = w.config.FailStatus
+
+	sql, args, err := sq.Update(w.config.Table).
+		Set("status", w.config.ProcessStatus).
+		Set("attempts", 0).
+		Set("process_at", "now()").
+		Set("lock_time", nil).
+		Set("updated_at", "now()").
+		Where(filter.where()).
+		// wakes the listening workers
+		Suffix("RETURNING pg_notify(?, '')", w.notifyChannel()).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := w.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to requeue tasks")
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (w *worker) CancelTasks(ctx context.Context, filter TaskFilter) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "pgtaskpool", "Cancel tasks")
+	defer span.End()
+
+	if err := filter.checkBulk(); err != nil {
+		return 0, err
+	}
+
+	filter.Status = w.config.ProcessStatus
+
+	sql, args, err := sq.Update(w.config.Table).
+		Set("status", w.config.CanceledStatus).
+		Set("updated_at", "now()").
+		Where(filter.where()).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := w.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to cancel tasks")
+	}
+
+	return tag.RowsAffected(), nil
+}
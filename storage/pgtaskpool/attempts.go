@@ -0,0 +1,182 @@
+package pgtaskpool
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/tracing"
+)
+
+// Attempt is a single run of the task handler kept in the attempts history
+type Attempt struct {
+	ID              uint64    `db:"id" json:"id"`
+	TaskID          uint64    `db:"task_id" json:"task_id"`
+	Attempt         int       `db:"attempt" json:"attempt"`
+	StartedAt       time.Time `db:"started_at" json:"started_at"`
+	FinishedAt      time.Time `db:"finished_at" json:"finished_at"`
+	DurationSeconds float64   `db:"duration_seconds" json:"duration_seconds"`
+	// ErrorType is the errors.Type name of the failed attempt, empty on success
+	ErrorType    *string `db:"error_type" json:"error_type,omitempty"`
+	ErrorMessage *string `db:"error_message" json:"error_message,omitempty"`
+	TraceID      string  `db:"trace_id" json:"trace_id"`
+}
+
+var attemptColumns = []string{
+	"id",
+	"task_id",
+	"attempt",
+	"started_at",
+	"finished_at",
+	"duration_seconds",
+	"error_type",
+	"error_message",
+	"trace_id",
+}
+
+func newAttempt(task Task, startedAt time.Time, traceID string, err error) Attempt {
+	finishedAt := time.Now()
+
+	attempt := Attempt{
+		TaskID:          task.ID,
+		Attempt:         task.Attempts,
+		StartedAt:       startedAt,
+		FinishedAt:      finishedAt,
+		DurationSeconds: finishedAt.Sub(startedAt).Seconds(),
+		TraceID:         traceID,
+	}
+
+	if err != nil {
+		errorType := ctxerrors.ErrorType(err).String()
+		errorMessage := err.Error()
+
+		attempt.ErrorType = &errorType
+		attempt.ErrorMessage = &errorMessage
+	}
+
+	return attempt
+}
+
+func (w *worker) attemptsTable() string {
+	return w.config.Table + "_attempts"
+}
+
+func (w *worker) insertAttempt(ctx context.Context, db goKitPgx.ExtContext, attempt Attempt) error {
+	sql, args, err := sq.Insert(w.attemptsTable()).
+		Columns(attemptColumns[1:]...).
+		Values(
+			attempt.TaskID,
+			attempt.Attempt,
+			attempt.StartedAt,
+			attempt.FinishedAt,
+			attempt.DurationSeconds,
+			attempt.ErrorType,
+			attempt.ErrorMessage,
+			attempt.TraceID,
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = db.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to insert attempt")
+	}
+
+	return nil
+}
+
+// ListAttempts returns the attempts history of the task, the first attempt first
+func (w *worker) ListAttempts(ctx context.Context, taskID uint64) ([]Attempt, error) {
+	ctx, span := tracing.StartSpan(ctx, "pgtaskpool", "List attempts")
+	defer span.End()
+
+	if !w.config.AttemptsHistory {
+		return nil, ctxerrors.New(ctxerrors.TypeNotImplemented, "attempts history is disabled")
+	}
+
+	return w.listAttempts(ctx, w.storage, taskID)
+}
+
+func (w *worker) listAttempts(ctx context.Context, db goKitPgx.ExtContext, taskID uint64) ([]Attempt, error) {
+	sql, args, err := sq.Select(attemptColumns...).
+		From(w.attemptsTable()).
+		Where(sq.Eq{"task_id": taskID}).
+		OrderBy("attempt", "id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to list attempts")
+	}
+
+	attempts, err := pgx.CollectRows(rows, pgx.RowToStructByName[Attempt])
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect attempts")
+	}
+
+	return attempts, nil
+}
+
+// cleanAttempts deletes the attempts older than the retention period until the context is done
+func (w *worker) cleanAttempts(ctx context.Context) {
+	ticker := time.NewTicker(w.config.AttemptsCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		count, err := w.deleteAttempts(ctx, time.Now().Add(-w.config.AttemptsRetention))
+		if err != nil {
+			w.logger.
+				WithCtx(ctx).
+				WithError(err).
+				Error("failed to clean attempts")
+
+			continue
+		}
+
+		w.logger.
+			WithCtx(ctx).
+			With("count", count).
+			Debug("attempts cleaned")
+	}
+}
+
+func (w *worker) deleteAttempts(ctx context.Context, before time.Time) (int64, error) {
+	sql, args, err := sq.Delete(w.attemptsTable()).
+		Where(sq.Lt{"finished_at": before}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := w.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to delete attempts")
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// attemptsHistory returns the stored attempts of the task or the last one when the history is disabled
+func (w *worker) attemptsHistory(ctx context.Context, db goKitPgx.ExtContext, attempt Attempt) ([]Attempt, error) {
+	if !w.config.AttemptsHistory {
+		return []Attempt{attempt}, nil
+	}
+
+	return w.listAttempts(ctx, db, attempt.TaskID)
+}
@@ -0,0 +1,28 @@
+package pgtaskpool
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+)
+
+func TestWorker_FairTasks(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	w := New(lg, nil, WithPartitionWeights(map[string]int{"b": 3, "a": 2, "c": 0})).(*worker)
+	assert.True(t, w.config.Fairness)
+
+	sql, args, err := w.fairTasks(sq.Eq{"status": statusProcess}, 5).PlaceholderFormat(sq.Dollar).ToSql()
+	require.NoError(t, err)
+
+	assert.Equal(t, "SELECT id FROM (SELECT id, priority, "+
+		"row_number() OVER (PARTITION BY partition_key ORDER BY priority DESC, id) AS rn, "+
+		"(CASE partition_key WHEN $1 THEN $2::integer WHEN $3 THEN $4::integer ELSE 1 END) AS weight "+
+		"FROM tasks WHERE status = $5) AS ranked "+
+		"ORDER BY ceil(rn::numeric / weight), priority DESC, id ASC LIMIT 5", sql)
+	assert.Equal(t, []interface{}{"a", 2, "b", 3, statusProcess}, args)
+}
@@ -25,4 +25,9 @@ const (
 	defaultTaskTable = "tasks"
 )
 
-var ErrNeedJustToRetry = errors.New("need just to retry")
+var (
+	ErrNeedJustToRetry = errors.New("need just to retry")
+	// ErrLeaseLost is the cancellation cause of the handler context when the task is taken
+	// by another worker or canceled, the result of the handler is not saved
+	ErrLeaseLost = errors.New("task lease lost")
+)
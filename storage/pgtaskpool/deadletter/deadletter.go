@@ -0,0 +1,58 @@
+// Package deadletter publishes the failed pgtaskpool tasks to the message brokers.
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/segmentio/kafka-go"
+	"github.com/underbek/examples-go/storage/pgtaskpool"
+	kafkatransport "github.com/underbek/examples-go/transport/kafka"
+	"github.com/underbek/examples-go/transport/rabbitmq"
+)
+
+// Kafka publishes the failed event as a json message keyed by the task kind and transaction id.
+// The empty topic is the one of the producer.
+func Kafka(producer kafkatransport.Producer, topic string) pgtaskpool.FailedHook {
+	return func(ctx context.Context, event pgtaskpool.FailedEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal failed event: %w", err)
+		}
+
+		return producer.Publish(ctx, kafka.Message{
+			Topic: topic,
+			Key:   []byte(key(event.Task)),
+			Value: data,
+			Time:  event.FailedAt,
+		})
+	}
+}
+
+// RabbitMQ publishes the failed event as a persistent json message with the routing key
+func RabbitMQ(producer *rabbitmq.Producer, routingKey string) pgtaskpool.FailedHook {
+	return func(ctx context.Context, event pgtaskpool.FailedEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal failed event: %w", err)
+		}
+
+		return producer.Publish(ctx, rabbitmq.PublishMessage{
+			RoutingKey: routingKey,
+			Message: amqp.Publishing{
+				ContentType:  "application/json",
+				DeliveryMode: amqp.Persistent,
+				MessageId:    key(event.Task),
+				Timestamp:    event.FailedAt,
+				Body:         data,
+			},
+		})
+	}
+}
+
+func key(task pgtaskpool.Task) string {
+	return task.Kind + ":" + strconv.FormatUint(task.TransactionID, 10)
+}
@@ -0,0 +1,88 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/storage/pgtaskpool"
+	"github.com/underbek/examples-go/transport/rabbitmq"
+)
+
+type testProducer struct {
+	messages []kafka.Message
+}
+
+func (p *testProducer) Publish(_ context.Context, msg kafka.Message) error {
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *testProducer) Close() error {
+	return nil
+}
+
+func testEvent() pgtaskpool.FailedEvent {
+	message := "[Internal] boom"
+
+	return pgtaskpool.FailedEvent{
+		Task: pgtaskpool.Task{
+			ID:            1,
+			TransactionID: 10,
+			Kind:          "send_email",
+			Type:          pgtaskpool.ScheduleTypeDefault,
+			Trace:         &pgtaskpool.Trace{TraceID: "trace", SpanID: "span"},
+		},
+		Attempts: []pgtaskpool.Attempt{{TaskID: 1, Attempt: 1, ErrorMessage: &message, TraceID: "trace"}},
+		FailedAt: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestKafka(t *testing.T) {
+	producer := &testProducer{}
+	event := testEvent()
+
+	require.NoError(t, Kafka(producer, "dead_tasks")(context.Background(), event))
+	require.Len(t, producer.messages, 1)
+
+	msg := producer.messages[0]
+	assert.Equal(t, "dead_tasks", msg.Topic)
+	assert.Equal(t, "send_email:10", string(msg.Key))
+	assert.Equal(t, event.FailedAt, msg.Time)
+
+	var published pgtaskpool.FailedEvent
+	require.NoError(t, json.Unmarshal(msg.Value, &published))
+	assert.Equal(t, event, published)
+}
+
+func TestRabbitMQ(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	event := testEvent()
+
+	ch := rabbitmq.NewChannelMock(t)
+	ch.On("PublishWithContext", mock.Anything, "exchange", "dead_tasks", false, false, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(5).(amqp.Publishing)
+			assert.Equal(t, "application/json", msg.ContentType)
+			assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+			assert.Equal(t, "send_email:10", msg.MessageId)
+
+			var published pgtaskpool.FailedEvent
+			require.NoError(t, json.Unmarshal(msg.Body, &published))
+			assert.Equal(t, event, published)
+		}).
+		Return(nil).
+		Once()
+
+	hook := RabbitMQ(rabbitmq.NewProducer(lg, ch, "exchange"), "dead_tasks")
+	require.NoError(t, hook(context.Background(), event))
+}
@@ -0,0 +1,87 @@
+package pgtaskpool
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+)
+
+type (
+	// FailedEvent describes the task failed for good with its attempts,
+	// the full history is kept with WithAttemptsHistory, otherwise it is the last attempt only
+	FailedEvent struct {
+		Task     Task      `json:"task"`
+		Attempts []Attempt `json:"attempts"`
+		FailedAt time.Time `json:"failed_at"`
+	}
+
+	// FailedHook reacts on the failed task, see WithOnFailed
+	FailedHook func(context.Context, FailedEvent) error
+)
+
+func (w *worker) deadLetterTable() string {
+	return w.config.Table + "_dead"
+}
+
+// onFailed calls the failed hooks one by one, their errors are logged
+func (w *worker) onFailed(ctx context.Context, task Task, attempt Attempt) {
+	if len(w.config.OnFailed) == 0 {
+		return
+	}
+
+	attempts, err := w.attemptsHistory(ctx, w.storage, attempt)
+	if err != nil {
+		w.logger.
+			WithCtx(ctx).
+			WithError(err).
+			With("task_id", task.ID).
+			Error("failed to get attempts history")
+
+		attempts = []Attempt{attempt}
+	}
+
+	event := FailedEvent{
+		Task:     task,
+		Attempts: attempts,
+		FailedAt: attempt.FinishedAt,
+	}
+
+	for _, hook := range w.config.OnFailed {
+		if err = hook(ctx, event); err != nil {
+			w.logger.
+				WithCtx(ctx).
+				WithError(err).
+				With("task_id", task.ID).
+				Error("failed hook failed")
+		}
+	}
+}
+
+// moveToDeadLetter moves the failed task with its attempts history to the dead letter table
+func (w *worker) moveToDeadLetter(ctx context.Context, db goKitPgx.ExtContext, task Task, attempt Attempt) error {
+	attempts, err := w.attemptsHistory(ctx, db, attempt)
+	if err != nil {
+		return err
+	}
+
+	history, err := json.Marshal(attempts)
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to marshal attempts history")
+	}
+
+	columns := strings.Join(taskColumns, ", ")
+
+	sql := "WITH moved AS (DELETE FROM " + w.config.Table + " WHERE id = $1 RETURNING " + columns + ") " +
+		"INSERT INTO " + w.deadLetterTable() + " (" + columns + ", attempts_history, failed_at) " +
+		"SELECT " + columns + ", $2::jsonb, now() FROM moved"
+
+	if _, err = db.Exec(ctx, sql, task.ID, history); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to move task to dead letter table")
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package pgtaskpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+)
+
+func TestWorker_OnFailed(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	var events []FailedEvent
+	hook := func(_ context.Context, event FailedEvent) error {
+		events = append(events, event)
+		return nil
+	}
+
+	w := New(lg, &leaseStorage{extends: 2}, WithOnFailed(hook)).(*worker)
+
+	router := NewRouter()
+	router.Handle("fail", func(context.Context, Task) error {
+		panic("boom")
+	}, WithRouteMaxAttempts(1))
+
+	ctx := context.Background()
+
+	task, attempt := w.process(ctx, Task{ID: 1, Kind: "fail", TransactionID: 10}, router.route("fail"))
+	require.NoError(t, w.saveTask(ctx, task, attempt))
+
+	require.Len(t, events, 1)
+	assert.Equal(t, task, events[0].Task)
+	assert.Equal(t, []Attempt{attempt}, events[0].Attempts)
+	assert.Equal(t, attempt.FinishedAt, events[0].FailedAt)
+
+	// the retried task is not failed yet
+	router.Handle("retry", func(context.Context, Task) error {
+		panic("boom")
+	})
+
+	task, attempt = w.process(ctx, Task{ID: 2, Kind: "retry", Type: ScheduleTypeDefault}, router.route("retry"))
+	require.NoError(t, w.saveTask(ctx, task, attempt))
+	assert.Len(t, events, 1)
+}
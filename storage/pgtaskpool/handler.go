@@ -0,0 +1,231 @@
+package pgtaskpool
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+type (
+	adminHandler struct {
+		admin Admin
+	}
+
+	countResponse struct {
+		Count int64 `json:"count"`
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// NewHandler exposes the admin API as JSON for the internal tooling.
+// Mount it with http.StripPrefix, the routes are:
+//
+//	GET  /tasks           list the tasks
+//	GET  /tasks/{id}      get the task
+//	GET  /tasks/{id}/attempts  list the attempts of the task
+//	POST /tasks/requeue   requeue the failed tasks
+//	POST /tasks/cancel    cancel the processing tasks
+//
+// The tasks are filtered by the query parameters status, kind, transaction_id (repeated),
+// error_code, created_from and created_to (RFC 3339), limit and offset.
+// The requeue and cancel without the filter are rejected unless all=true is passed.
+func NewHandler(admin Admin) http.Handler {
+	return &adminHandler{
+		admin: admin,
+	}
+}
+
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+
+	switch {
+	case path == "tasks" && r.Method == http.MethodGet:
+		h.listTasks(w, r)
+	case path == "tasks/requeue" && r.Method == http.MethodPost:
+		h.requeueTasks(w, r)
+	case path == "tasks/cancel" && r.Method == http.MethodPost:
+		h.cancelTasks(w, r)
+	case strings.HasPrefix(path, "tasks/") && strings.HasSuffix(path, "/attempts") && r.Method == http.MethodGet:
+		h.listAttempts(w, r, strings.TrimSuffix(strings.TrimPrefix(path, "tasks/"), "/attempts"))
+	case strings.HasPrefix(path, "tasks/") && r.Method == http.MethodGet:
+		h.getTask(w, r, strings.TrimPrefix(path, "tasks/"))
+	default:
+		writeError(w, ctxerrors.Errorf(ctxerrors.TypeNotFound, "route %s %s is not exists", r.Method, r.URL.Path))
+	}
+}
+
+func (h *adminHandler) listTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tasks, err := h.admin.ListTasks(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, tasks)
+}
+
+func (h *adminHandler) getTask(w http.ResponseWriter, r *http.Request, rawID string) {
+	id, err := parseTaskID(rawID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	task, err := h.admin.GetTask(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, task)
+}
+
+func (h *adminHandler) listAttempts(w http.ResponseWriter, r *http.Request, rawID string) {
+	id, err := parseTaskID(rawID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	attempts, err := h.admin.ListAttempts(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, attempts)
+}
+
+func (h *adminHandler) requeueTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	count, err := h.admin.RequeueTasks(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, countResponse{Count: count})
+}
+
+func (h *adminHandler) cancelTasks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTaskFilter(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	count, err := h.admin.CancelTasks(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, countResponse{Count: count})
+}
+
+func parseTaskID(raw string) (uint64, error) {
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "invalid task id %q", raw)
+	}
+
+	return id, nil
+}
+
+func parseTaskFilter(r *http.Request) (TaskFilter, error) {
+	query := r.URL.Query()
+
+	filter := TaskFilter{
+		Status: query.Get("status"),
+	}
+
+	if query.Has("kind") {
+		kind := query.Get("kind")
+		filter.Kind = &kind
+	}
+
+	for _, raw := range query["transaction_id"] {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return TaskFilter{}, ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "invalid transaction_id %q", raw)
+		}
+
+		filter.TransactionIDs = append(filter.TransactionIDs, id)
+	}
+
+	parsers := []struct {
+		name  string
+		parse func(string) error
+	}{
+		{"error_code", func(raw string) error {
+			code, err := strconv.ParseUint(raw, 10, 64)
+			filter.ErrorCode = &code
+			return err
+		}},
+		{"created_from", func(raw string) error {
+			from, err := time.Parse(time.RFC3339, raw)
+			filter.CreatedFrom = &from
+			return err
+		}},
+		{"created_to", func(raw string) error {
+			to, err := time.Parse(time.RFC3339, raw)
+			filter.CreatedTo = &to
+			return err
+		}},
+		{"limit", func(raw string) (err error) {
+			filter.Limit, err = strconv.ParseUint(raw, 10, 64)
+			return err
+		}},
+		{"offset", func(raw string) (err error) {
+			filter.Offset, err = strconv.ParseUint(raw, 10, 64)
+			return err
+		}},
+		{"all", func(raw string) (err error) {
+			filter.All, err = strconv.ParseBool(raw)
+			return err
+		}},
+	}
+
+	for _, parser := range parsers {
+		raw := query.Get(parser.name)
+		if raw == "" {
+			continue
+		}
+
+		if err := parser.parse(raw); err != nil {
+			return TaskFilter{}, ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "invalid %s %q", parser.name, raw)
+		}
+	}
+
+	return filter, nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code, _ := ctxerrors.ParseHttpError(err)
+	writeResponse(w, code, errorResponse{Error: err.Error()})
+}
+
+func writeResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(data)
+}
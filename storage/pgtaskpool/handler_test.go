@@ -0,0 +1,203 @@
+package pgtaskpool
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+)
+
+type testAdmin struct {
+	filter TaskFilter
+	tasks  map[uint64]Task
+}
+
+func (a *testAdmin) ListTasks(_ context.Context, filter TaskFilter) ([]Task, error) {
+	a.filter = filter
+
+	tasks := make([]Task, 0, len(a.tasks))
+	for _, task := range a.tasks {
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func (a *testAdmin) GetTask(_ context.Context, id uint64) (Task, error) {
+	task, ok := a.tasks[id]
+	if !ok {
+		return Task{}, ctxerrors.Errorf(ctxerrors.TypeNotFound, "task with id %d is not exists", id)
+	}
+
+	return task, nil
+}
+
+func (a *testAdmin) ListAttempts(_ context.Context, id uint64) ([]Attempt, error) {
+	if _, ok := a.tasks[id]; !ok {
+		return nil, ctxerrors.Errorf(ctxerrors.TypeNotFound, "task with id %d is not exists", id)
+	}
+
+	return []Attempt{{ID: 1, TaskID: id, Attempt: 1, TraceID: "trace"}}, nil
+}
+
+func (a *testAdmin) RequeueTasks(_ context.Context, filter TaskFilter) (int64, error) {
+	a.filter = filter
+	return 2, nil
+}
+
+func (a *testAdmin) CancelTasks(_ context.Context, filter TaskFilter) (int64, error) {
+	a.filter = filter
+	return 3, nil
+}
+
+func TestHandler(t *testing.T) {
+	status := statusFailed
+	admin := &testAdmin{tasks: map[uint64]Task{
+		1: {ID: 1, TransactionID: 10, Kind: "send_email", Status: &status, Payload: []byte(`{"to":"user@example.com"}`)},
+	}}
+
+	server := httptest.NewServer(http.StripPrefix("/admin", NewHandler(admin)))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		body   string
+		filter TaskFilter
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			path:   "/admin/tasks?status=failed&kind=&transaction_id=10&transaction_id=11&error_code=5&created_from=2024-11-01T00:00:00Z&limit=10",
+			code:   http.StatusOK,
+			body:   `[{"id":1,"transaction_id":10,"kind":"send_email","payload":{"to":"user@example.com"},"attempts":0,"schedule_type":"","lease_token":0,"status":"failed"}]`,
+			filter: TaskFilter{
+				Status:         statusFailed,
+				Kind:           new(string),
+				TransactionIDs: []uint64{10, 11},
+				ErrorCode:      &[]uint64{5}[0],
+				CreatedFrom:    &[]time.Time{time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)}[0],
+				Limit:          10,
+			},
+		},
+		{
+			name:   "get",
+			method: http.MethodGet,
+			path:   "/admin/tasks/1",
+			code:   http.StatusOK,
+			body:   `{"id":1,"transaction_id":10,"kind":"send_email","payload":{"to":"user@example.com"},"attempts":0,"schedule_type":"","lease_token":0,"status":"failed"}`,
+		},
+		{
+			name:   "get not found",
+			method: http.MethodGet,
+			path:   "/admin/tasks/2",
+			code:   http.StatusNotFound,
+			body:   `{"error":"[NotFound] task with id 2 is not exists"}`,
+		},
+		{
+			name:   "attempts",
+			method: http.MethodGet,
+			path:   "/admin/tasks/1/attempts",
+			code:   http.StatusOK,
+			body:   `[{"id":1,"task_id":1,"attempt":1,"started_at":"0001-01-01T00:00:00Z","finished_at":"0001-01-01T00:00:00Z","duration_seconds":0,"trace_id":"trace"}]`,
+		},
+		{
+			name:   "requeue",
+			method: http.MethodPost,
+			path:   "/admin/tasks/requeue?transaction_id=10",
+			code:   http.StatusOK,
+			body:   `{"count":2}`,
+			filter: TaskFilter{TransactionIDs: []uint64{10}},
+		},
+		{
+			name:   "cancel",
+			method: http.MethodPost,
+			path:   "/admin/tasks/cancel?kind=send_email",
+			code:   http.StatusOK,
+			body:   `{"count":3}`,
+			filter: TaskFilter{Kind: &[]string{"send_email"}[0]},
+		},
+		{
+			name:   "cancel all",
+			method: http.MethodPost,
+			path:   "/admin/tasks/cancel?all=true",
+			code:   http.StatusOK,
+			body:   `{"count":3}`,
+			filter: TaskFilter{All: true},
+		},
+		{
+			name:   "invalid all",
+			method: http.MethodPost,
+			path:   "/admin/tasks/requeue?all=yes",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "invalid filter",
+			method: http.MethodGet,
+			path:   "/admin/tasks?created_to=yesterday",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown route",
+			method: http.MethodDelete,
+			path:   "/admin/tasks/1",
+			code:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			admin.filter = TaskFilter{}
+
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, tt.filter, admin.filter)
+
+			if tt.body != "" {
+				var body []byte
+				body, err = io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.body, string(body))
+			}
+		})
+	}
+}
+
+func TestWorker_BulkEmptyFilter(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	w := New(lg, nil).(*worker)
+	ctx := context.Background()
+
+	_, err = w.RequeueTasks(ctx, TaskFilter{})
+	assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(err))
+
+	_, err = w.CancelTasks(ctx, TaskFilter{Status: statusProcess, Limit: 10})
+	assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(err))
+
+	server := httptest.NewServer(NewHandler(w))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/tasks/cancel", "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"runtime/debug"
 	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
 )
 
 func (w *worker) collectTasksMetrics() (uint32, uint32) {
@@ -44,21 +46,35 @@ func (w *worker) checkTask(task *Task) error {
 		task.Type = ScheduleTypeDefault
 	}
 
+	if task.Cron != "" && task.IntervalSec != 0 {
+		return ctxerrors.New(ctxerrors.TypeInvalidRequest, "declared both cron and interval recurrence")
+	}
+
+	if task.Cron != "" {
+		if _, err := task.nextOccurrence(time.Now()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func taskAttempts(t Task) []uint {
+func taskAttempts(t Task, rt route) []uint {
 	if len(t.CustomScheduleSlice) > 0 || t.Type == ScheduleTypeCustom {
 		return t.CustomScheduleSlice
 	}
 
+	if len(rt.schedule) > 0 {
+		return rt.schedule
+	}
+
 	defaultTask := new(Task)
 	defaultTask.GenerateDefaultSchedule(defaultLifetime)
 
 	return defaultTask.CustomScheduleSlice
 }
 
-func (w *worker) recoverHandler(ctx context.Context, handler handler, task Task) error {
+func (w *worker) recoverHandler(ctx context.Context, handler Handler, task Task) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			w.logger.WithCtx(ctx).
@@ -66,8 +82,10 @@ func (w *worker) recoverHandler(ctx context.Context, handler handler, task Task)
 				With("panic", r).
 				With("trace", string(debug.Stack())).
 				Error(fmt.Sprintf("Recovered from pgtaskpool panic: %v", r))
+
+			err = ctxerrors.Errorf(ctxerrors.TypeInternal, "task handler panicked: %v", r)
 		}
 	}()
 
-	return handler(ctx, task.TransactionID)
+	return handler(ctx, task)
 }
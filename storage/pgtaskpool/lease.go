@@ -0,0 +1,90 @@
+package pgtaskpool
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+// keepLease extends the lock of the claimed task in the background until stop is called.
+// The returned context is canceled with the ErrLeaseLost cause once the lease is lost:
+// the task is claimed by another worker, canceled or the lock could not be extended before it expired.
+func (w *worker) keepLease(ctx context.Context, task Task) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(w.config.LeaseInterval)
+		defer ticker.Stop()
+
+		expireAt := time.Now().Add(w.config.BlockTimeout)
+		if task.LockTime != nil {
+			expireAt = *task.LockTime
+		}
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			lockTime := time.Now().Add(w.config.BlockTimeout)
+
+			extended, err := w.extendLease(ctx, task, lockTime)
+			if err != nil {
+				w.logger.
+					WithCtx(ctx).
+					WithError(err).
+					With("task_id", task.ID).
+					Warn("failed to extend task lease")
+
+				if time.Now().Before(expireAt) {
+					continue
+				}
+			}
+
+			if !extended {
+				cancel(ErrLeaseLost)
+				return
+			}
+
+			expireAt = lockTime
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		<-stopped
+		cancel(nil)
+	}
+}
+
+func (w *worker) extendLease(ctx context.Context, task Task, lockTime time.Time) (bool, error) {
+	sql, args, err := sq.Update(w.config.Table).
+		Set("lock_time", lockTime).
+		Where(sq.Eq{
+			"id":          task.ID,
+			"lease_token": task.LeaseToken,
+			"status":      w.config.ProcessStatus,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := w.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to extend lease")
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
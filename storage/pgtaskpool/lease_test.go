@@ -0,0 +1,92 @@
+package pgtaskpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+)
+
+// leaseStorage extends the lease the number of times and fails the rest
+type leaseStorage struct {
+	goKitPgx.Storage
+
+	mtx     sync.Mutex
+	extends int
+	calls   int
+}
+
+func (s *leaseStorage) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.calls++
+	if s.calls <= s.extends {
+		return pgconn.NewCommandTag("UPDATE 1"), nil
+	}
+
+	return pgconn.NewCommandTag("UPDATE 0"), nil
+}
+
+func (s *leaseStorage) callCount() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.calls
+}
+
+func newLeaseWorker(t *testing.T, storage goKitPgx.Storage) *worker {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	return New(lg, storage, WithBlockTimeout(time.Second), WithLeaseInterval(time.Millisecond*10)).(*worker)
+}
+
+func TestWorker_KeepLease(t *testing.T) {
+	storage := &leaseStorage{extends: 3}
+	w := newLeaseWorker(t, storage)
+
+	ctx, stop := w.keepLease(context.Background(), Task{ID: 1, LeaseToken: 1})
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("lease is not lost")
+	}
+
+	assert.ErrorIs(t, context.Cause(ctx), ErrLeaseLost)
+	assert.Equal(t, 4, storage.callCount())
+}
+
+func TestWorker_KeepLeaseStop(t *testing.T) {
+	storage := &leaseStorage{extends: 1000}
+	w := newLeaseWorker(t, storage)
+
+	ctx, stop := w.keepLease(context.Background(), Task{ID: 1, LeaseToken: 1})
+
+	time.Sleep(time.Millisecond * 50)
+	assert.NoError(t, ctx.Err())
+
+	stop()
+	calls := storage.callCount()
+	assert.Positive(t, calls)
+
+	// no extending after the handler is done
+	time.Sleep(time.Millisecond * 30)
+	assert.Equal(t, calls, storage.callCount())
+	assert.ErrorIs(t, context.Cause(ctx), context.Canceled)
+}
+
+func TestWorker_UpdateTaskFenced(t *testing.T) {
+	w := newLeaseWorker(t, &leaseStorage{})
+
+	err := w.updateTask(context.Background(), w.storage, Task{ID: 1, LeaseToken: 1})
+	assert.ErrorIs(t, err, ErrLeaseLost)
+}
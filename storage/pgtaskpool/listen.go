@@ -0,0 +1,78 @@
+package pgtaskpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+const notifyChannelPrefix = "pgtaskpool_"
+
+func (w *worker) notifyChannel() string {
+	return notifyChannelPrefix + w.config.Table
+}
+
+// listen wakes the fetch loop on the notifications of the created tasks until the context is done.
+// The broken connection is reestablished, the tasks created meanwhile are found by polling.
+func (w *worker) listen(ctx context.Context, wake chan<- struct{}) {
+	for {
+		err := w.listenConn(ctx, wake)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.logger.
+			WithCtx(ctx).
+			WithError(err).
+			Warn("listen failed")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.config.ListenRetryInterval):
+		}
+	}
+}
+
+func (w *worker) listenConn(ctx context.Context, wake chan<- struct{}) error {
+	conn, err := pgx.Connect(ctx, w.config.ListenDSN)
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to connect")
+	}
+
+	defer func() {
+		cCtx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+		defer cancel()
+
+		if err := conn.Close(cCtx); err != nil {
+			w.logger.
+				WithCtx(cCtx).
+				WithError(err).
+				Error("failed to close listen connection")
+		}
+	}()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{w.notifyChannel()}.Sanitize()); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to listen")
+	}
+
+	// the tasks created before the listen started
+	wakeUp(wake)
+
+	for {
+		if _, err = conn.WaitForNotification(ctx); err != nil {
+			return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to wait for notification")
+		}
+
+		wakeUp(wake)
+	}
+}
+
+func wakeUp(wake chan<- struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
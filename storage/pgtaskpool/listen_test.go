@@ -0,0 +1,93 @@
+package pgtaskpool
+
+import (
+	"context"
+	"time"
+)
+
+// the sync interval of the listening worker is too long to find the task by polling in the tests
+const listenSyncInterval = time.Hour
+
+// listenPID returns the backend of the worker LISTEN connection, zero until it listens
+func (s *TestSuite) listenPID() int32 {
+	var pid int32
+	err := s.db.QueryRow(
+		context.Background(),
+		"SELECT COALESCE(MAX(pid), 0) FROM pg_stat_activity WHERE query LIKE 'LISTEN %' AND pid <> pg_backend_pid()",
+	).Scan(&pid)
+	s.Require().NoError(err)
+
+	return pid
+}
+
+// runListening runs the listening worker and returns the transaction ids of the handled tasks
+func (s *TestSuite) runListening(ctx context.Context) (Worker, <-chan uint64, <-chan struct{}) {
+	w := New(s.logger, s.db,
+		WithListen(s.postgresContainer.GetDSN()),
+		WithListenRetryInterval(time.Millisecond*100),
+	)
+
+	handled := make(chan uint64, 10)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_ = w.Run(ctx, listenSyncInterval, func(_ context.Context, id uint64) error {
+			handled <- id
+			return nil
+		})
+	}()
+
+	s.Require().Eventually(func() bool {
+		return s.listenPID() != 0
+	}, time.Second*10, time.Millisecond*50)
+
+	return w, handled, done
+}
+
+func (s *TestSuite) awaitHandled(handled <-chan uint64, id uint64) {
+	select {
+	case got := <-handled:
+		s.Equal(id, got)
+	case <-time.After(time.Second * 5):
+		s.Fail("task is not handled before the sync interval")
+	}
+}
+
+func (s *TestSuite) TestListen_WakesWorker() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w, handled, done := s.runListening(ctx)
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	s.Require().NoError(w.Create(ctx, Task{TransactionID: 1}))
+	s.awaitHandled(handled, 1)
+}
+
+func (s *TestSuite) TestListen_Reconnects() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w, handled, done := s.runListening(ctx)
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	pid := s.listenPID()
+
+	_, err := s.db.Exec(ctx, "SELECT pg_terminate_backend($1)", pid)
+	s.Require().NoError(err)
+
+	// the worker listens again on the new connection
+	s.Require().Eventually(func() bool {
+		current := s.listenPID()
+		return current != 0 && current != pid
+	}, time.Second*10, time.Millisecond*50)
+
+	s.Require().NoError(w.Create(ctx, Task{TransactionID: 2}))
+	s.awaitHandled(handled, 2)
+}
@@ -12,6 +12,10 @@ const (
 	//labels
 	metricsTaskType   = "task_type"
 	metricsTaskStatus = "task_status"
+	metricsAction     = "action"
+
+	partitionCreated = "created"
+	partitionDropped = "dropped"
 )
 
 type counter interface {
@@ -19,8 +23,10 @@ type counter interface {
 }
 
 type taskSchedulerMetrics struct {
-	tasksCount    *prometheus.CounterVec
-	tasksDuration *prometheus.HistogramVec
+	tasksCount        *prometheus.CounterVec
+	tasksDuration     *prometheus.HistogramVec
+	tasksRetained     *prometheus.CounterVec
+	partitionsChanged *prometheus.CounterVec
 }
 
 type counterMetrics struct {
@@ -51,11 +57,31 @@ func newSchedulerMetrics(enable bool) taskSchedulerMetrics {
 			},
 			[]string{metricsTaskType, metricsTaskStatus},
 		),
+		tasksRetained: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "tasks_retained_count",
+				Help:      "Total number of archived or deleted finished tasks",
+			},
+			[]string{metricsAction},
+		),
+		partitionsChanged: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "archive_partitions_count",
+				Help:      "Total number of created or dropped archive partitions",
+			},
+			[]string{metricsAction},
+		),
 	}
 
 	if enable {
 		prometheus.MustRegister(m.tasksCount)
 		prometheus.MustRegister(m.tasksDuration)
+		prometheus.MustRegister(m.tasksRetained)
+		prometheus.MustRegister(m.partitionsChanged)
 	}
 
 	return m
@@ -75,6 +101,16 @@ func (m taskSchedulerMetrics) registerTaskDuration(task Task, duration float64)
 	return m
 }
 
+func (m taskSchedulerMetrics) addRetained(mode RetentionMode, count int64) taskSchedulerMetrics {
+	m.tasksRetained.With(prometheus.Labels{metricsAction: string(mode)}).Add(float64(count))
+	return m
+}
+
+func (m taskSchedulerMetrics) incPartitions(action string) taskSchedulerMetrics {
+	m.partitionsChanged.With(prometheus.Labels{metricsAction: action}).Inc()
+	return m
+}
+
 func (m taskSchedulerMetrics) labels(task Task) prometheus.Labels {
 	status := statusProcess
 
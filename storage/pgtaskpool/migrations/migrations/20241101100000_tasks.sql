@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tasks
+(
+    id                 bigserial primary key,
+    transaction_id     bigint    not null,
+    status             varchar   not null,
+    custom_schedule    integer[],
+    schedule_type      varchar   not null,
+    attempts           integer   not null default 0,
+    trace_meta         jsonb,
+    last_error_code    bigint,
+    last_error_message varchar,
+    process_at         timestamp not null,
+    lock_time          timestamp,
+    created_at         timestamp not null default now(),
+    updated_at         timestamp not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS tasks_status_process_at_idx ON tasks (status, process_at);
+CREATE INDEX IF NOT EXISTS tasks_transaction_id_idx ON tasks (transaction_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tasks;
+-- +goose StatementEnd
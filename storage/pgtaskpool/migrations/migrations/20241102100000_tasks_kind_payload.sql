@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS kind    varchar not null default '',
+    ADD COLUMN IF NOT EXISTS payload jsonb;
+
+CREATE INDEX IF NOT EXISTS tasks_status_kind_process_at_idx ON tasks (status, kind, process_at);
+DROP INDEX IF EXISTS tasks_status_process_at_idx;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS tasks_status_process_at_idx ON tasks (status, process_at);
+DROP INDEX IF EXISTS tasks_status_kind_process_at_idx;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS payload,
+    DROP COLUMN IF EXISTS kind;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS created_at timestamp not null default now(),
+    ADD COLUMN IF NOT EXISTS updated_at timestamp not null default now();
+
+CREATE INDEX IF NOT EXISTS tasks_created_at_idx ON tasks (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS tasks_created_at_idx;
+-- +goose StatementEnd
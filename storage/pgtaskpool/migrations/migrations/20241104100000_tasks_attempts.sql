@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tasks_attempts
+(
+    id               bigserial primary key,
+    task_id          bigint           not null,
+    attempt          integer          not null,
+    started_at       timestamp        not null,
+    finished_at      timestamp        not null,
+    duration_seconds double precision not null,
+    error_type       varchar,
+    error_message    varchar,
+    trace_id         varchar          not null
+);
+
+CREATE INDEX IF NOT EXISTS tasks_attempts_task_id_idx ON tasks_attempts (task_id);
+CREATE INDEX IF NOT EXISTS tasks_attempts_finished_at_idx ON tasks_attempts (finished_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tasks_attempts;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS cron         varchar not null default '',
+    ADD COLUMN IF NOT EXISTS interval_sec bigint  not null default 0,
+    ADD COLUMN IF NOT EXISTS time_zone    varchar not null default '';
+
+-- only one pending occurrence of the recurring task
+CREATE UNIQUE INDEX IF NOT EXISTS tasks_recurring_uniq_idx ON tasks (kind, transaction_id)
+    WHERE status = 'process' AND (cron <> '' OR interval_sec <> 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS tasks_recurring_uniq_idx;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS time_zone,
+    DROP COLUMN IF EXISTS interval_sec,
+    DROP COLUMN IF EXISTS cron;
+-- +goose StatementEnd
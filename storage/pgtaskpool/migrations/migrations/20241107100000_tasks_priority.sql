@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS priority      integer not null default 0,
+    ADD COLUMN IF NOT EXISTS partition_key varchar not null default '';
+
+CREATE INDEX IF NOT EXISTS tasks_status_kind_priority_id_idx ON tasks (status, kind, priority DESC, id);
+CREATE INDEX IF NOT EXISTS tasks_status_kind_partition_key_priority_id_idx
+    ON tasks (status, kind, partition_key, priority DESC, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS tasks_status_kind_partition_key_priority_id_idx;
+DROP INDEX IF EXISTS tasks_status_kind_priority_id_idx;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS partition_key,
+    DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
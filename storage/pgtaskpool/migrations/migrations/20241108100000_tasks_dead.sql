@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tasks_dead
+(
+    id                 bigint primary key,
+    transaction_id     bigint    not null,
+    kind               varchar   not null,
+    payload            jsonb,
+    attempts           integer   not null,
+    custom_schedule    integer[],
+    cron               varchar   not null,
+    interval_sec       bigint    not null,
+    time_zone          varchar   not null,
+    priority           integer   not null,
+    partition_key      varchar   not null,
+    schedule_type      varchar   not null,
+    trace_meta         jsonb,
+    last_error_code    bigint,
+    last_error_message varchar,
+    status             varchar   not null,
+    process_at         timestamp not null,
+    lock_time          timestamp,
+    lease_token        bigint    not null,
+    created_at         timestamp not null,
+    updated_at         timestamp not null,
+    attempts_history   jsonb     not null,
+    failed_at          timestamp not null
+);
+
+CREATE INDEX IF NOT EXISTS tasks_dead_kind_transaction_id_idx ON tasks_dead (kind, transaction_id);
+CREATE INDEX IF NOT EXISTS tasks_dead_failed_at_idx ON tasks_dead (failed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tasks_dead;
+-- +goose StatementEnd
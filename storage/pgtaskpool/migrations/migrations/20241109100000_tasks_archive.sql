@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tasks_archive
+(
+    id                 bigint    not null,
+    transaction_id     bigint    not null,
+    kind               varchar   not null,
+    payload            jsonb,
+    attempts           integer   not null,
+    custom_schedule    integer[],
+    cron               varchar   not null,
+    interval_sec       bigint    not null,
+    time_zone          varchar   not null,
+    priority           integer   not null,
+    partition_key      varchar   not null,
+    schedule_type      varchar   not null,
+    trace_meta         jsonb,
+    last_error_code    bigint,
+    last_error_message varchar,
+    status             varchar   not null,
+    process_at         timestamp not null,
+    lock_time          timestamp,
+    lease_token        bigint    not null,
+    created_at         timestamp not null,
+    updated_at         timestamp not null,
+    archived_at        timestamp not null
+);
+
+CREATE INDEX IF NOT EXISTS tasks_archive_id_idx ON tasks_archive (id);
+CREATE INDEX IF NOT EXISTS tasks_archive_archived_at_idx ON tasks_archive (archived_at);
+
+CREATE INDEX IF NOT EXISTS tasks_status_updated_at_idx ON tasks (status, updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS tasks_status_updated_at_idx;
+DROP TABLE IF EXISTS tasks_archive;
+-- +goose StatementEnd
@@ -12,19 +12,48 @@ type options struct {
 	EnableMetrics         bool
 	MetricsCollectTimeout time.Duration
 	BlockTimeout          time.Duration
+	// LeaseInterval is the period of extending the lock of the running task, a third of BlockTimeout by default
+	LeaseInterval       time.Duration
+	BatchSize           int
+	Concurrency         int
+	ListenDSN           string
+	ListenRetryInterval time.Duration
+	// AttemptsHistory writes every attempt to the table named after the tasks one with the _attempts suffix
+	AttemptsHistory         bool
+	AttemptsRetention       time.Duration
+	AttemptsCleanupInterval time.Duration
+	// Fairness claims the tasks round-robin across the partition keys weighted by PartitionWeights, 1 by default
+	Fairness         bool
+	PartitionWeights map[string]int
+	// DeadLetterTable moves the failed tasks to the table named after the tasks one with the _dead suffix
+	DeadLetterTable bool
+	OnFailed        []FailedHook
+	// RetentionMode archives or deletes the finished tasks older than RetentionAge, empty keeps them
+	RetentionMode      RetentionMode
+	RetentionAge       time.Duration
+	RetentionBatchSize int
+	RetentionInterval  time.Duration
+	ArchivePartitions  *ArchivePartitions
 }
 
 func defaultWorkerOptions() options {
 	return options{
-		MaxAttempts:           10,
-		ProcessStatus:         statusProcess,
-		FailStatus:            statusFailed,
-		SuccessStatus:         statusSuccess,
-		CanceledStatus:        statusCancelled,
-		Table:                 defaultTaskTable,
-		EnableMetrics:         false,
-		MetricsCollectTimeout: time.Second * 5,
-		BlockTimeout:          time.Minute * 5,
+		MaxAttempts:             10,
+		ProcessStatus:           statusProcess,
+		FailStatus:              statusFailed,
+		SuccessStatus:           statusSuccess,
+		CanceledStatus:          statusCancelled,
+		Table:                   defaultTaskTable,
+		EnableMetrics:           false,
+		MetricsCollectTimeout:   time.Second * 5,
+		BlockTimeout:            time.Minute * 5,
+		BatchSize:               1,
+		Concurrency:             1,
+		ListenRetryInterval:     time.Second * 5,
+		AttemptsRetention:       time.Hour * 24 * 30,
+		AttemptsCleanupInterval: time.Hour,
+		RetentionBatchSize:      1000,
+		RetentionInterval:       time.Minute,
 	}
 }
 
@@ -108,3 +137,130 @@ func WithBlockTimeout(dur time.Duration) WorkerOption {
 		o.BlockTimeout = dur
 	})
 }
+
+// WithLeaseInterval Option to determine how often the lock of the running task is extended,
+// it must be shorter than the block timeout
+func WithLeaseInterval(dur time.Duration) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.LeaseInterval = dur
+	})
+}
+
+// WithBatchSize Option to claim up to n due tasks with one query.
+// The batch is capped by the free slots of WithConcurrency, so n above the concurrency has no effect
+func WithBatchSize(n int) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		if n > 0 {
+			o.BatchSize = n
+		}
+	})
+}
+
+// WithConcurrency Option to run up to n task handlers in parallel by one service instance
+func WithConcurrency(n int) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		if n > 0 {
+			o.Concurrency = n
+		}
+	})
+}
+
+// WithListen Option to wake the worker on the created tasks at once.
+// The worker holds a dedicated LISTEN connection to the database by the dsn, polling stays as a fallback
+func WithListen(dsn string) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.ListenDSN = dsn
+	})
+}
+
+// WithListenRetryInterval Option to determine the delay before reconnecting the broken LISTEN connection
+func WithListenRetryInterval(dur time.Duration) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.ListenRetryInterval = dur
+	})
+}
+
+// WithAttemptsHistory Option to keep every attempt of the tasks with its timing, error and trace id.
+// The attempts older than the retention are deleted by the running worker each cleanup interval
+func WithAttemptsHistory(retention, cleanupInterval time.Duration) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.AttemptsHistory = true
+
+		if retention > 0 {
+			o.AttemptsRetention = retention
+		}
+
+		if cleanupInterval > 0 {
+			o.AttemptsCleanupInterval = cleanupInterval
+		}
+	})
+}
+
+// WithFairness Option to claim the due tasks round-robin across their partition keys,
+// so a noisy tenant does not starve the others
+func WithFairness() WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.Fairness = true
+	})
+}
+
+// WithPartitionWeights Option to claim the due tasks fairly giving a partition its weight of tasks per round,
+// the partitions without the weight get one
+func WithPartitionWeights(weights map[string]int) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.Fairness = true
+		o.PartitionWeights = make(map[string]int, len(weights))
+
+		for key, weight := range weights {
+			if weight > 0 {
+				o.PartitionWeights[key] = weight
+			}
+		}
+	})
+}
+
+// WithOnFailed Option to call the hooks when a task fails for good, after its result is saved.
+// The hooks run one by one in the worker taking its slot, their errors are logged
+func WithOnFailed(hooks ...FailedHook) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.OnFailed = append(o.OnFailed, hooks...)
+	})
+}
+
+// WithDeadLetterTable Option to move the failed tasks with their attempts history to the dead letter table
+// in the transaction saving the result
+func WithDeadLetterTable() WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.DeadLetterTable = true
+	})
+}
+
+// WithRetention Option to archive or delete the succeeded and canceled tasks older than the age.
+// The running worker processes them by batches each retention interval
+func WithRetention(mode RetentionMode, age time.Duration) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.RetentionMode = mode
+		o.RetentionAge = age
+	})
+}
+
+// WithRetentionBatch Option to determine the batch size and the run interval of the retention
+func WithRetentionBatch(size int, interval time.Duration) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		if size > 0 {
+			o.RetentionBatchSize = size
+		}
+
+		if interval > 0 {
+			o.RetentionInterval = interval
+		}
+	})
+}
+
+// WithArchivePartitions Option to manage the partitions of the archive table partitioned by range of archived_at.
+// The current and ahead partitions are created, the ones older than keep are dropped each retention interval
+func WithArchivePartitions(partitions ArchivePartitions) WorkerOption {
+	return newFuncWorkerOption(func(o *options) {
+		o.ArchivePartitions = &partitions
+	})
+}
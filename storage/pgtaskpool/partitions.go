@@ -0,0 +1,150 @@
+package pgtaskpool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+// PartitionPeriod is the time range of an archive partition
+type PartitionPeriod string
+
+const (
+	PartitionDaily   PartitionPeriod = "daily"
+	PartitionMonthly PartitionPeriod = "monthly"
+
+	partitionSuffixLayout = "20060102"
+)
+
+// ArchivePartitions manages the archive table partitioned by range of archived_at
+type ArchivePartitions struct {
+	Period PartitionPeriod
+	// Ahead is the number of the partitions created after the current one
+	Ahead int
+	// Keep is the age of the partitions to drop, zero keeps them forever
+	Keep time.Duration
+}
+
+// start truncates the time to the beginning of its partition
+func (p ArchivePartitions) start(t time.Time) time.Time {
+	t = t.UTC()
+
+	if p.Period == PartitionMonthly {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// next returns the beginning of the partition after the one starting at the time
+func (p ArchivePartitions) next(start time.Time) time.Time {
+	if p.Period == PartitionMonthly {
+		return start.AddDate(0, 1, 0)
+	}
+
+	return start.AddDate(0, 0, 1)
+}
+
+func (w *worker) partitionName(start time.Time) string {
+	return w.archiveTable() + "_p" + start.Format(partitionSuffixLayout)
+}
+
+// managePartitions creates the current and ahead archive partitions and drops the outdated ones
+func (w *worker) managePartitions(ctx context.Context, now time.Time) error {
+	partitions := *w.config.ArchivePartitions
+
+	names, err := w.listPartitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		existing[name] = struct{}{}
+	}
+
+	start := partitions.start(now)
+	for i := 0; i <= partitions.Ahead; i++ {
+		end := partitions.next(start)
+		name := w.partitionName(start)
+
+		if _, ok := existing[name]; !ok {
+			sql := fmt.Sprintf(
+				"CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+				pgx.Identifier{name}.Sanitize(),
+				pgx.Identifier{w.archiveTable()}.Sanitize(),
+				start.Format(time.DateOnly),
+				end.Format(time.DateOnly),
+			)
+
+			if _, err = w.storage.Exec(ctx, sql); err != nil {
+				return ctxerrors.Wrapf(err, ctxerrors.TypeDatabase, "failed to create partition %s", name)
+			}
+
+			w.metrics.incPartitions(partitionCreated)
+		}
+
+		start = end
+	}
+
+	if partitions.Keep == 0 {
+		return nil
+	}
+
+	for _, name := range w.outdatedPartitions(names, now) {
+		if _, err = w.storage.Exec(ctx, "DROP TABLE IF EXISTS "+pgx.Identifier{name}.Sanitize()); err != nil {
+			return ctxerrors.Wrapf(err, ctxerrors.TypeDatabase, "failed to drop partition %s", name)
+		}
+
+		w.metrics.incPartitions(partitionDropped)
+	}
+
+	return nil
+}
+
+// outdatedPartitions returns the managed partitions ending before the keep age
+func (w *worker) outdatedPartitions(names []string, now time.Time) []string {
+	partitions := *w.config.ArchivePartitions
+	prefix := w.archiveTable() + "_p"
+	threshold := now.Add(-partitions.Keep)
+
+	var outdated []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		start, err := time.Parse(partitionSuffixLayout, strings.TrimPrefix(name, prefix))
+		if err != nil {
+			continue
+		}
+
+		if !partitions.next(start).After(threshold) {
+			outdated = append(outdated, name)
+		}
+	}
+
+	return outdated
+}
+
+func (w *worker) listPartitions(ctx context.Context) ([]string, error) {
+	rows, err := w.storage.Query(ctx, `SELECT child.relname::text
+		FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.relname = $1`, w.archiveTable())
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to list partitions")
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect partitions")
+	}
+
+	return names, nil
+}
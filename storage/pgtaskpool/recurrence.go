@@ -0,0 +1,79 @@
+package pgtaskpool
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// IsRecurring reports whether the next occurrence is created after the task is done
+func (t Task) IsRecurring() bool {
+	return t.Cron != "" || t.IntervalSec != 0
+}
+
+// nextOccurrence returns the first occurrence of the recurring task after the time in the location of the time.
+// The cron expression is evaluated in the task time zone, but process_at is a timestamp without zone
+// written by its wall clock, so the result must not stay in the task time zone.
+func (t Task) nextOccurrence(after time.Time) (time.Time, error) {
+	if t.IntervalSec != 0 {
+		return after.Add(time.Second * time.Duration(t.IntervalSec)), nil
+	}
+
+	location := time.UTC
+	if t.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(t.TimeZone); err != nil {
+			return time.Time{}, ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "invalid time zone %q", t.TimeZone)
+		}
+	}
+
+	schedule, err := cronParser.Parse(t.Cron)
+	if err != nil {
+		return time.Time{}, ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "invalid cron expression %q", t.Cron)
+	}
+
+	next := schedule.Next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, ctxerrors.Errorf(ctxerrors.TypeInvalidRequest, "cron expression %q has no occurrences", t.Cron)
+	}
+
+	return next.In(after.Location()), nil
+}
+
+// firstProcessAt is the time to run the created task, the cron task waits for its occurrence
+// unless the initial delay is set. The task is validated by checkTask before.
+func (w *worker) firstProcessAt(task Task) time.Time {
+	now := time.Now()
+
+	if task.Cron != "" && task.InitDelaySec == 0 {
+		if next, err := task.nextOccurrence(now); err == nil {
+			return next
+		}
+	}
+
+	return now.Add(time.Second * time.Duration(task.InitDelaySec))
+}
+
+// nextTask is the next occurrence of the done recurring task
+func (t Task) nextTask(after time.Time) (Task, time.Time, error) {
+	processAt, err := t.nextOccurrence(after)
+	if err != nil {
+		return Task{}, time.Time{}, err
+	}
+
+	return Task{
+		TransactionID:       t.TransactionID,
+		Kind:                t.Kind,
+		Payload:             t.Payload,
+		CustomScheduleSlice: t.CustomScheduleSlice,
+		Type:                t.Type,
+		Cron:                t.Cron,
+		IntervalSec:         t.IntervalSec,
+		TimeZone:            t.TimeZone,
+		Priority:            t.Priority,
+		PartitionKey:        t.PartitionKey,
+	}, processAt, nil
+}
@@ -0,0 +1,114 @@
+package pgtaskpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+func TestTask_NextOccurrence(t *testing.T) {
+	after := time.Date(2024, 11, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		task    Task
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "interval",
+			task: Task{IntervalSec: 90},
+			want: after.Add(time.Second * 90),
+		},
+		{
+			name: "cron in UTC",
+			task: Task{Cron: "0 * * * *"},
+			want: time.Date(2024, 11, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "cron in time zone",
+			task: Task{Cron: "0 9 * * *", TimeZone: "Europe/Moscow"},
+			want: time.Date(2024, 11, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "descriptor",
+			task: Task{Cron: "@daily"},
+			want: time.Date(2024, 11, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid cron",
+			task:    Task{Cron: "every minute"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			task:    Task{Cron: "@daily", TimeZone: "Mars/Olympus"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.task.IsRecurring())
+
+			next, err := tt.task.nextOccurrence(after)
+			if tt.wantErr {
+				assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(next), "want %s, got %s", tt.want, next)
+			// process_at keeps the wall clock, it must be the one of the after location
+			assert.Equal(t, after.Location(), next.Location())
+			assert.Equal(t, tt.want.Hour(), next.Hour())
+		})
+	}
+}
+
+func TestTask_NextTask(t *testing.T) {
+	task := Task{
+		ID:            1,
+		TransactionID: 10,
+		Kind:          "cleanup",
+		Payload:       []byte(`{}`),
+		Attempts:      3,
+		Type:          ScheduleTypeDefault,
+		Cron:          "*/5 * * * *",
+		LeaseToken:    2,
+	}
+
+	next, processAt, err := task.nextTask(time.Date(2024, 11, 1, 10, 31, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, Task{
+		TransactionID: 10,
+		Kind:          "cleanup",
+		Payload:       []byte(`{}`),
+		Type:          ScheduleTypeDefault,
+		Cron:          "*/5 * * * *",
+	}, next)
+	assert.True(t, time.Date(2024, 11, 1, 10, 35, 0, 0, time.UTC).Equal(processAt))
+}
+
+func TestWorker_CheckRecurringTask(t *testing.T) {
+	w := newTestWorker(t)
+
+	assert.Error(t, w.checkTask(&Task{Cron: "@hourly", IntervalSec: 60}))
+	assert.Error(t, w.checkTask(&Task{Cron: "* *"}))
+	assert.NoError(t, w.checkTask(&Task{Cron: "@hourly", TimeZone: "Asia/Tokyo"}))
+
+	processAt := w.firstProcessAt(Task{Cron: "@hourly"})
+	assert.Zero(t, processAt.Minute())
+	assert.True(t, processAt.After(time.Now()))
+
+	processAt = w.firstProcessAt(Task{Cron: "0 9 * * *", TimeZone: "Europe/Moscow"})
+	assert.Equal(t, time.Local, processAt.Location())
+	assert.Equal(t, 9, processAt.In(time.FixedZone("MSK", 3*60*60)).Hour())
+
+	assert.WithinDuration(t, time.Now(), w.firstProcessAt(Task{IntervalSec: 60}), time.Second)
+}
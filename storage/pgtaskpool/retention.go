@@ -0,0 +1,120 @@
+package pgtaskpool
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+// RetentionMode is what happens to the finished tasks older than the retention age
+type RetentionMode string
+
+const (
+	// RetentionDelete deletes the finished tasks
+	RetentionDelete RetentionMode = "delete"
+	// RetentionArchive moves the finished tasks to the table named after the tasks one with the _archive suffix
+	RetentionArchive RetentionMode = "archive"
+)
+
+func (w *worker) archiveTable() string {
+	return w.config.Table + "_archive"
+}
+
+// retain archives or deletes the old finished tasks and manages the archive partitions
+// each retention interval until the context is done
+func (w *worker) retain(ctx context.Context) {
+	ticker := time.NewTicker(w.config.RetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if w.config.ArchivePartitions != nil {
+			if err := w.managePartitions(ctx, time.Now().UTC()); err != nil {
+				w.logger.
+					WithCtx(ctx).
+					WithError(err).
+					Error("failed to manage archive partitions")
+			}
+		}
+
+		if w.config.RetentionMode != "" {
+			w.retainTasks(ctx)
+		}
+	}
+}
+
+// retainTasks processes the old finished tasks by batches while the batches are full
+func (w *worker) retainTasks(ctx context.Context) {
+	before := time.Now().Add(-w.config.RetentionAge)
+
+	for ctx.Err() == nil {
+		count, err := w.retainBatch(ctx, before)
+		if err != nil {
+			w.logger.
+				WithCtx(ctx).
+				WithError(err).
+				Error("failed to retain tasks")
+
+			return
+		}
+
+		w.metrics.addRetained(w.config.RetentionMode, count)
+
+		if count < int64(w.config.RetentionBatchSize) {
+			return
+		}
+	}
+}
+
+func (w *worker) retainBatch(ctx context.Context, before time.Time) (int64, error) {
+	sql, args, err := w.retainQuery(before)
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := w.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to retain tasks")
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// retainQuery deletes a batch of the succeeded and canceled tasks updated before the time,
+// the archive mode inserts them to the archive table with the same statement.
+// The failed tasks are left for requeue, see WithDeadLetterTable.
+func (w *worker) retainQuery(before time.Time) (string, []interface{}, error) {
+	finished := sq.Select("id").
+		From(w.config.Table).
+		Where(sq.And{
+			sq.Eq{"status": []string{w.config.SuccessStatus, w.config.CanceledStatus}},
+			sq.Lt{"updated_at": before},
+		}).
+		OrderBy("id").
+		Limit(uint64(w.config.RetentionBatchSize)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	remove := sq.Delete(w.config.Table).
+		Where(sq.Expr("id IN (?)", finished))
+
+	if w.config.RetentionMode == RetentionDelete {
+		return remove.PlaceholderFormat(sq.Dollar).ToSql()
+	}
+
+	columns := strings.Join(taskColumns, ", ")
+
+	return remove.
+		Prefix("WITH moved AS (").
+		Suffix("RETURNING " + columns + ")").
+		Suffix("INSERT INTO " + w.archiveTable() + " (" + columns + ", archived_at) SELECT " + columns + ", now() FROM moved").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+}
@@ -0,0 +1,66 @@
+package pgtaskpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+)
+
+func newRetentionWorker(t *testing.T, opts ...WorkerOption) *worker {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	return New(lg, nil, opts...).(*worker)
+}
+
+func TestWorker_RetainQuery(t *testing.T) {
+	before := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	finished := "SELECT id FROM tasks WHERE (status IN ($1,$2) AND updated_at < $3) ORDER BY id LIMIT 100 FOR UPDATE SKIP LOCKED"
+
+	w := newRetentionWorker(t, WithRetention(RetentionDelete, time.Hour), WithRetentionBatch(100, 0))
+
+	sql, args, err := w.retainQuery(before)
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM tasks WHERE id IN ("+finished+")", sql)
+	assert.Equal(t, []interface{}{statusSuccess, statusCancelled, before}, args)
+
+	w = newRetentionWorker(t, WithRetention(RetentionArchive, time.Hour), WithRetentionBatch(100, 0))
+
+	sql, _, err = w.retainQuery(before)
+	require.NoError(t, err)
+	assert.Contains(t, sql, "WITH moved AS ( DELETE FROM tasks WHERE id IN ("+finished+") RETURNING id, ")
+	assert.Contains(t, sql, ", updated_at) INSERT INTO tasks_archive (id, ")
+	assert.Contains(t, sql, ", updated_at, archived_at) SELECT id, ")
+	assert.Contains(t, sql, ", updated_at, now() FROM moved")
+}
+
+func TestArchivePartitions(t *testing.T) {
+	now := time.Date(2024, 11, 20, 15, 0, 0, 0, time.UTC)
+
+	daily := ArchivePartitions{Period: PartitionDaily}
+	assert.Equal(t, time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC), daily.start(now))
+	assert.Equal(t, time.Date(2024, 11, 21, 0, 0, 0, 0, time.UTC), daily.next(daily.start(now)))
+
+	monthly := ArchivePartitions{Period: PartitionMonthly}
+	assert.Equal(t, time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), monthly.start(now))
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), monthly.next(monthly.start(now)))
+
+	w := newRetentionWorker(t, WithArchivePartitions(ArchivePartitions{
+		Period: PartitionMonthly,
+		Keep:   time.Hour * 24 * 60,
+	}))
+
+	assert.Equal(t, "tasks_archive_p20241101", w.partitionName(monthly.start(now)))
+	// the september partition keeps the rows newer than the keep age
+	assert.Equal(t, []string{"tasks_archive_p20240801"}, w.outdatedPartitions([]string{
+		"tasks_archive_p20240801",
+		"tasks_archive_p20240901",
+		"tasks_archive_p20241001",
+		"tasks_archive_p20241101",
+		"tasks_archive_default",
+		"other_p20200101",
+	}, now))
+}
@@ -0,0 +1,110 @@
+package pgtaskpool
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+type (
+	// Handler processes the task of a kind
+	Handler func(context.Context, Task) error
+
+	RouteOption func(*route)
+
+	route struct {
+		handler     Handler
+		maxAttempts int
+		schedule    []uint
+	}
+
+	// Router dispatches the tasks to the handlers of their kinds.
+	// The worker running the router claims only the tasks of the registered kinds.
+	Router struct {
+		routes map[string]route
+	}
+)
+
+// WithRouteMaxAttempts Option to fail the task of the kind after n attempts even if its schedule is not over
+func WithRouteMaxAttempts(n int) RouteOption {
+	return func(r *route) {
+		r.maxAttempts = n
+	}
+}
+
+// WithRouteSchedule Option to define the delays in seconds before each attempt of the kind tasks
+// created with the default schedule, the first delay is skipped as the task is created with InitDelaySec
+func WithRouteSchedule(schedule ...uint) RouteOption {
+	return func(r *route) {
+		r.schedule = schedule
+	}
+}
+
+func NewRouter() *Router {
+	return &Router{
+		routes: make(map[string]route),
+	}
+}
+
+// Handle registers the handler of the kind
+func (r *Router) Handle(kind string, handler Handler, opts ...RouteOption) {
+	rt := route{handler: handler}
+	for _, opt := range opts {
+		opt(&rt)
+	}
+
+	r.routes[kind] = rt
+}
+
+// HandlePayload registers the handler of the kind getting the payload decoded into T.
+// A payload that cannot be decoded fails the attempt.
+func HandlePayload[T any](r *Router, kind string, handler func(context.Context, Task, T) error, opts ...RouteOption) {
+	r.Handle(kind, func(ctx context.Context, task Task) error {
+		var payload T
+		if err := task.DecodePayload(&payload); err != nil {
+			return err
+		}
+
+		return handler(ctx, task, payload)
+	}, opts...)
+}
+
+func (r *Router) kinds() []string {
+	kinds := make([]string, 0, len(r.routes))
+	for kind := range r.routes {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+func (r *Router) route(kind string) route {
+	rt, ok := r.routes[kind]
+	if !ok {
+		return route{
+			handler: func(context.Context, Task) error {
+				return ctxerrors.Errorf(ctxerrors.TypeNotImplemented, "no handler for the task kind %q", kind)
+			},
+		}
+	}
+
+	return rt
+}
+
+// NewTask builds the task of the kind with the payload stored as JSON
+func NewTask[T any](kind string, transactionID uint64, payload T) (Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Task{}, ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, "failed to marshal task payload")
+	}
+
+	return Task{
+		Kind:          kind,
+		TransactionID: transactionID,
+		Payload:       data,
+	}, nil
+}
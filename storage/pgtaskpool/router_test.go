@@ -0,0 +1,88 @@
+package pgtaskpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+)
+
+type testEmail struct {
+	To string `json:"to"`
+}
+
+func newTestWorker(t *testing.T) *worker {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	return New(lg, nil).(*worker)
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWorker(t)
+
+	var sent []string
+
+	router := NewRouter()
+	router.Handle("", func(context.Context, Task) error {
+		return nil
+	})
+	HandlePayload(router, "send_email", func(_ context.Context, _ Task, email testEmail) error {
+		sent = append(sent, email.To)
+		return nil
+	}, WithRouteMaxAttempts(2))
+
+	assert.Equal(t, []string{"", "send_email"}, router.kinds())
+
+	task, err := NewTask("send_email", 1, testEmail{To: "user@example.com"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"to": "user@example.com"}`, string(task.Payload))
+
+	task, _ = w.process(ctx, task, router.route(task.Kind))
+	assert.Equal(t, statusSuccess, *task.Status)
+	assert.Equal(t, []string{"user@example.com"}, sent)
+
+	task, _ = w.process(ctx, Task{Kind: "send_email", Payload: []byte(`{"to": 1}`)}, router.route("send_email"))
+	assert.Nil(t, task.Status)
+	assert.Equal(t, uint64(ctxerrors.TypeInvalidRequest), *task.LastErrorCode)
+
+	task, _ = w.process(ctx, task, router.route("send_email"))
+	assert.Equal(t, statusFailed, *task.Status)
+	assert.Equal(t, 2, task.Attempts)
+
+	task, _ = w.process(ctx, Task{Kind: "unknown"}, router.route("unknown"))
+	assert.Equal(t, uint64(ctxerrors.TypeNotImplemented), *task.LastErrorCode)
+}
+
+func TestRouter_Schedule(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWorker(t)
+
+	router := NewRouter()
+	router.Handle("panic", func(context.Context, Task) error {
+		panic("boom")
+	}, WithRouteSchedule(0, 10))
+
+	rt := router.route("panic")
+	assert.Equal(t, []uint{0, 10}, taskAttempts(Task{Type: ScheduleTypeDefault}, rt))
+	assert.Equal(t, []uint{1, 2}, taskAttempts(Task{Type: ScheduleTypeCustom, CustomScheduleSlice: []uint{1, 2}}, rt))
+
+	// the panic fails the attempt
+	task, attempt := w.process(ctx, Task{ID: 7, Kind: "panic", Type: ScheduleTypeDefault}, rt)
+	assert.Nil(t, task.Status)
+	assert.Equal(t, uint64(ctxerrors.TypeInternal), *task.LastErrorCode)
+
+	assert.Equal(t, uint64(7), attempt.TaskID)
+	assert.Equal(t, 1, attempt.Attempt)
+	assert.Equal(t, ctxerrors.TypeInternal.String(), *attempt.ErrorType)
+	assert.Equal(t, "[Internal] task handler panicked: boom", *attempt.ErrorMessage)
+	assert.False(t, attempt.FinishedAt.Before(attempt.StartedAt))
+
+	task, attempt = w.process(ctx, task, rt)
+	assert.Equal(t, statusFailed, *task.Status)
+	assert.Equal(t, 2, attempt.Attempt)
+}
@@ -2,6 +2,9 @@ package pgtaskpool
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -12,33 +15,86 @@ import (
 	"github.com/underbek/examples-go/tracing"
 )
 
-func (w *worker) createTask(ctx context.Context, task Task) error {
+// taskColumns are the columns of Task
+var taskColumns = []string{
+	"id",
+	"transaction_id",
+	"kind",
+	"payload",
+	"attempts",
+	"custom_schedule",
+	"cron",
+	"interval_sec",
+	"time_zone",
+	"priority",
+	"partition_key",
+	"schedule_type",
+	"trace_meta",
+	"last_error_code",
+	"last_error_message",
+	"status",
+	"process_at",
+	"lock_time",
+	"lease_token",
+	"created_at",
+	"updated_at",
+}
+
+func (w *worker) createTask(ctx context.Context, db goKitPgx.ExtContext, task Task, processAt time.Time) error {
 	ctx, span := tracing.StartSpan(ctx, "pgtaskpool", "Create task")
 	defer span.End()
 
 	meta := logger.ParseCtxMeta(ctx)
 
-	sql, args, err := sq.Insert(w.config.Table).
+	var payload interface{}
+	if len(task.Payload) != 0 {
+		payload = task.Payload
+	}
+
+	query := sq.Insert(w.config.Table).
 		Columns(
 			"transaction_id",
+			"kind",
+			"payload",
 			"status",
 			"custom_schedule",
+			"cron",
+			"interval_sec",
+			"time_zone",
+			"priority",
+			"partition_key",
 			"schedule_type",
 			"process_at",
 			"trace_meta",
 		).
 		Values(
 			task.TransactionID,
+			task.Kind,
+			payload,
 			w.config.ProcessStatus,
 			task.CustomScheduleSlice,
+			task.Cron,
+			task.IntervalSec,
+			task.TimeZone,
+			task.Priority,
+			task.PartitionKey,
 			task.Type,
-			time.Now().Add(time.Second*time.Duration(task.InitDelaySec)),
+			processAt,
 			Trace{
 				TraceID: span.SpanContext().TraceID().String(),
 				SpanID:  span.SpanContext().SpanID().String(),
 				Meta:    meta,
 			},
 		).
+		// the pending occurrence of the recurring task is unique
+		Suffix("ON CONFLICT DO NOTHING")
+
+	// wakes the listening workers, the delayed task is found by polling
+	if !processAt.After(time.Now()) {
+		query = query.Suffix("RETURNING pg_notify(?, '')", w.notifyChannel())
+	}
+
+	sql, args, err := query.
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 
@@ -46,7 +102,7 @@ func (w *worker) createTask(ctx context.Context, task Task) error {
 		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
 	}
 
-	_, err = w.storage.Exec(ctx, sql, args...)
+	_, err = db.Exec(ctx, sql, args...)
 	if err != nil {
 		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to make query")
 	}
@@ -54,7 +110,7 @@ func (w *worker) createTask(ctx context.Context, task Task) error {
 	return nil
 }
 
-func (w *worker) cancelTask(ctx context.Context, id uint64) error {
+func (w *worker) cancelTask(ctx context.Context, db goKitPgx.ExtContext, id uint64) error {
 	sql, args, err := sq.Update(w.config.Table).
 		Where(sq.Eq{"transaction_id": id}).
 		Where(sq.Eq{"status": w.config.ProcessStatus}).
@@ -67,62 +123,186 @@ func (w *worker) cancelTask(ctx context.Context, id uint64) error {
 		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
 	}
 
-	_, err = w.storage.Exec(ctx, sql, args...)
+	_, err = db.Exec(ctx, sql, args...)
 	if err != nil {
 		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to make query")
 	}
 	return nil
 }
 
-func (w *worker) getTask(ctx context.Context) (Task, error) {
-	sql, args, err := sq.Select(
-		"id",
-		"transaction_id",
-		"attempts",
-		"custom_schedule",
-		"schedule_type",
-		"trace_meta",
-		"last_error_code",
-		"last_error_message",
-		"status",
-		"process_at",
-		"lock_time",
-	).
+// claimTasks locks up to limit due tasks for the block timeout with one query,
+// the tasks locked by other instances are skipped.
+// The tasks of the higher priority go first, the fair worker takes them by turns across the partitions.
+func (w *worker) claimTasks(ctx context.Context, limit int, kinds []string) ([]Task, error) {
+	isDue := sq.And{
+		sq.Eq{"status": w.config.ProcessStatus},
+		sq.Eq{"kind": kinds},
+		sq.LtOrEq{"process_at": "now()"},
+		sq.Or{
+			sq.Eq{"lock_time": nil},
+			sq.LtOrEq{"lock_time": "now()"},
+		},
+	}
+
+	due := sq.Select("id").
 		From(w.config.Table).
-		Where(
-			sq.And{
-				sq.Eq{"status": w.config.ProcessStatus},
-				sq.LtOrEq{"process_at": "now()"},
-				sq.Or{
-					sq.Eq{"lock_time": nil},
-					sq.LtOrEq{"lock_time": "now()"},
-				},
-			},
-		).
-		OrderBy("id ASC").
-		Suffix("FOR UPDATE SKIP LOCKED").
+		Where(isDue).
+		OrderBy("priority DESC", "id ASC").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	if w.config.Fairness {
+		// the due condition is checked again for the tasks claimed meanwhile
+		due = sq.Select("id").
+			From(w.config.Table).
+			Where(sq.And{
+				sq.Expr("id IN (?)", w.fairTasks(isDue, limit)),
+				isDue,
+			}).
+			Suffix("FOR UPDATE SKIP LOCKED")
+	}
+
+	sql, args, err := sq.Update(w.config.Table).
+		Set("lock_time", time.Now().Add(w.config.BlockTimeout)).
+		Set("lease_token", sq.Expr("lease_token + 1")).
+		Where(sq.Expr("id IN (?)", due)).
+		Suffix("RETURNING " + strings.Join(taskColumns, ", ")).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
-		return Task{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
 	}
 
 	rows, err := w.storage.Query(ctx, sql, args...)
 	if err != nil {
-		return Task{}, err
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to claim tasks")
 	}
 
-	defer rows.Close()
+	tasks, err := pgx.CollectRows(rows, pgx.RowToStructByName[Task])
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect tasks")
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority > tasks[j].Priority
+		}
 
-	task, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Task])
+		return tasks[i].ID < tasks[j].ID
+	})
+
+	return tasks, nil
+}
+
+// fairTasks selects the due tasks round-robin across the partitions, a partition with the weight n
+// gets n tasks per round. Within a partition the tasks go by priority.
+func (w *worker) fairTasks(isDue sq.Sqlizer, limit int) sq.SelectBuilder {
+	weight := sq.Case("partition_key").Else("1")
+	for _, key := range w.partitionKeys() {
+		weight = weight.When(sq.Expr("?", key), sq.Expr("?::integer", w.config.PartitionWeights[key]))
+	}
+
+	ranked := sq.Select("id", "priority").
+		Column("row_number() OVER (PARTITION BY partition_key ORDER BY priority DESC, id) AS rn").
+		Column(sq.Alias(weight, "weight")).
+		From(w.config.Table).
+		Where(isDue)
+
+	return sq.Select("id").
+		FromSelect(ranked, "ranked").
+		OrderBy("ceil(rn::numeric / weight)", "priority DESC", "id ASC").
+		Limit(uint64(limit))
+}
+
+func (w *worker) partitionKeys() []string {
+	keys := make([]string, 0, len(w.config.PartitionWeights))
+	for key := range w.config.PartitionWeights {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// saveTask stores the processing result of the task in one transaction with the attempt,
+// when the history is enabled, the next occurrence of the done recurring task
+// and the move of the failed task to the dead letter table. The failed hooks are called after the commit.
+func (w *worker) saveTask(ctx context.Context, task Task, attempt Attempt) error {
+	recur := task.IsRecurring() && task.Status != nil
+	failed := task.Status != nil && *task.Status == w.config.FailStatus
+	deadLetter := failed && w.config.DeadLetterTable
+
+	if !w.config.AttemptsHistory && !recur && !deadLetter {
+		if err := w.updateTask(ctx, w.storage, task); err != nil {
+			return err
+		}
+
+		if failed {
+			w.onFailed(ctx, task, attempt)
+		}
+
+		return nil
+	}
+
+	tx, err := w.storage.Begin(ctx, nil)
 	if err != nil {
-		return Task{}, err
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to begin transaction")
+	}
+
+	defer func() {
+		rCtx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+
+		if err = tx.Rollback(rCtx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			w.logger.
+				WithCtx(rCtx).
+				WithError(err).
+				Error("rollback failed")
+		}
+
+		cancel()
+	}()
+
+	if err = w.updateTask(ctx, tx, task); err != nil {
+		return err
+	}
+
+	if w.config.AttemptsHistory {
+		if err = w.insertAttempt(ctx, tx, attempt); err != nil {
+			return err
+		}
 	}
 
-	return task, nil
+	// the fenced update above lets only one worker schedule the occurrence
+	if recur {
+		next, processAt, err := task.nextTask(time.Now())
+		if err != nil {
+			return err
+		}
+
+		if err = w.createTask(ctx, tx, next, processAt); err != nil {
+			return err
+		}
+	}
+
+	if deadLetter {
+		if err = w.moveToDeadLetter(ctx, tx, task, attempt); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to commit transaction")
+	}
+
+	if failed {
+		w.onFailed(ctx, task, attempt)
+	}
+
+	return nil
 }
 
-func (w *worker) updateTask(ctx context.Context, task Task) error {
+func (w *worker) updateTask(ctx context.Context, db goKitPgx.ExtContext, task Task) error {
 	upd := sq.Update(w.config.Table).
 		Set("attempts", task.Attempts).
 		Set("updated_at", "now()").
@@ -144,22 +324,36 @@ func (w *worker) updateTask(ctx context.Context, task Task) error {
 		upd = upd.Set("process_at", task.NextProcessingTime)
 	}
 
-	sql, args, err := upd.Where(sq.Eq{"id": task.ID}).PlaceholderFormat(sq.Dollar).ToSql()
+	// the task claimed again or canceled meanwhile is not overwritten
+	sql, args, err := upd.
+		Where(sq.Eq{
+			"id":          task.ID,
+			"lease_token": task.LeaseToken,
+			"status":      w.config.ProcessStatus,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 	if err != nil {
 		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
 	}
 
-	if _, err := w.storage.Exec(ctx, sql, args...); err != nil {
+	tag, err := db.Exec(ctx, sql, args...)
+	if err != nil {
 		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to exec update query")
 	}
 
+	if tag.RowsAffected() == 0 {
+		return ctxerrors.Wrapf(ErrLeaseLost, ctxerrors.TypeInternal, "task %d is not updated", task.ID)
+	}
+
 	return nil
 }
 
-func (w *worker) isTaskExists(ctx context.Context, id uint64) (bool, error) {
+func (w *worker) isTaskExists(ctx context.Context, kind string, id uint64) (bool, error) {
 	sql, args, err := sq.Select("count(transaction_id)").From(w.config.Table).
 		Where(sq.And{
 			sq.Eq{"status": w.config.ProcessStatus},
+			sq.Eq{"kind": kind},
 			sq.Eq{"transaction_id": id},
 		}).
 		PlaceholderFormat(sq.Dollar).
@@ -210,24 +404,3 @@ func (w *worker) countTasks(ctx context.Context, filter tasksFilter) (uint32, er
 
 	return count, nil
 }
-
-func (w *worker) blockTask(ctx context.Context, tx goKitPgx.Transaction, task Task) error {
-	if task.LockTime == nil {
-		return ctxerrors.New(ctxerrors.TypeInternal, "lock time is nil")
-	}
-
-	sql, args, err := sq.Update(w.config.Table).
-		Set("lock_time", task.LockTime).
-		Where(sq.Eq{"id": task.ID}).
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
-	if err != nil {
-		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
-	}
-
-	if _, err := tx.Exec(ctx, sql, args...); err != nil {
-		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to exec update query")
-	}
-
-	return nil
-}
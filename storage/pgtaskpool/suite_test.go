@@ -0,0 +1,65 @@
+package pgtaskpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/underbek/examples-go/logger"
+	goKitMigrations "github.com/underbek/examples-go/migrate"
+	"github.com/underbek/examples-go/storage/pgtaskpool/migrations"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/testcontainers"
+)
+
+type TestSuite struct {
+	suite.Suite
+	postgresContainer *testcontainer.PostgresContainer
+	logger            *logger.Logger
+	db                goKitPgx.Storage
+}
+
+func TestSuiteWorker_Run(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
+
+func (s *TestSuite) SetupSuite() {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute*2)
+	defer ctxCancel()
+
+	var err error
+	s.logger, err = logger.New(true)
+	s.Require().NoError(err)
+
+	s.postgresContainer, err = testcontainer.NewPostgresContainer(ctx)
+	s.Require().NoError(err)
+
+	err = goKitMigrations.Run(
+		s.postgresContainer.GetDSN(),
+		goKitMigrations.WithFs(migrations.Migrations),
+		goKitMigrations.WithDriver("pgx"),
+		goKitMigrations.WithLogger(s.logger),
+	)
+	s.Require().NoError(err)
+
+	s.db, err = goKitPgx.New(
+		context.Background(),
+		goKitPgx.Config{DSN: s.postgresContainer.GetDSN(), Timeout: time.Minute},
+		goKitPgx.WithLogger(s.logger),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *TestSuite) TearDownSuite() {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer ctxCancel()
+
+	s.db.Close()
+	s.Require().NoError(s.postgresContainer.Terminate(ctx))
+}
+
+func (s *TestSuite) SetupTest() {
+	_, err := s.db.Exec(context.Background(), "TRUNCATE "+defaultTaskTable)
+	s.Require().NoError(err)
+}
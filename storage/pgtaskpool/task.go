@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
 )
 
 /*
@@ -27,18 +29,35 @@ type (
 	}
 
 	Task struct {
-		ID                  uint64       `db:"id"`
-		TransactionID       uint64       `db:"transaction_id"`
-		InitDelaySec        uint         `db:"-"`
-		CustomScheduleSlice []uint       `db:"custom_schedule"`
-		Attempts            int          `db:"attempts"`
-		Type                ScheduleType `db:"schedule_type"`
-		Trace               *Trace       `db:"trace_meta"`
-		LastErrorCode       *uint64      `db:"last_error_code"`
-		LastErrorMessage    *string      `db:"last_error_message"`
-		Status              *string      `db:"status"`
-		NextProcessingTime  *time.Time   `db:"process_at"`
-		LockTime            *time.Time   `db:"lock_time"`
+		ID            uint64 `db:"id" json:"id"`
+		TransactionID uint64 `db:"transaction_id" json:"transaction_id"`
+		// Kind routes the task to its handler, the tasks without kind are handled by Run
+		Kind string `db:"kind" json:"kind"`
+		// Payload is the JSON data of the task, see NewTask
+		Payload             json.RawMessage `db:"payload" json:"payload,omitempty"`
+		InitDelaySec        uint            `db:"-" json:"init_delay_sec,omitempty"`
+		CustomScheduleSlice []uint          `db:"custom_schedule" json:"custom_schedule,omitempty"`
+		// Cron or IntervalSec make the task recurring, the next occurrence is created once the task is done.
+		// The cron expression has five fields or a descriptor like @daily and is evaluated in the TimeZone, UTC by default
+		Cron        string `db:"cron" json:"cron,omitempty"`
+		IntervalSec uint   `db:"interval_sec" json:"interval_sec,omitempty"`
+		TimeZone    string `db:"time_zone" json:"time_zone,omitempty"`
+		// Priority orders the due tasks, the higher goes first
+		Priority int `db:"priority" json:"priority,omitempty"`
+		// PartitionKey groups the tasks of a tenant, the fair worker takes the partitions by turns
+		PartitionKey       string       `db:"partition_key" json:"partition_key,omitempty"`
+		Attempts           int          `db:"attempts" json:"attempts"`
+		Type               ScheduleType `db:"schedule_type" json:"schedule_type"`
+		Trace              *Trace       `db:"trace_meta" json:"trace,omitempty"`
+		LastErrorCode      *uint64      `db:"last_error_code" json:"last_error_code,omitempty"`
+		LastErrorMessage   *string      `db:"last_error_message" json:"last_error_message,omitempty"`
+		Status             *string      `db:"status" json:"status,omitempty"`
+		NextProcessingTime *time.Time   `db:"process_at" json:"process_at,omitempty"`
+		LockTime           *time.Time   `db:"lock_time" json:"lock_time,omitempty"`
+		// LeaseToken grows with every claim of the task, it fences the results of the stale workers
+		LeaseToken int64      `db:"lease_token" json:"lease_token"`
+		CreatedAt  *time.Time `db:"created_at" json:"created_at,omitempty"`
+		UpdatedAt  *time.Time `db:"updated_at" json:"updated_at,omitempty"`
 	}
 )
 
@@ -55,6 +74,19 @@ func (t *Trace) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, (*alias)(t))
 }
 
+// DecodePayload unmarshals the JSON payload of the task into the target
+func (t Task) DecodePayload(target interface{}) error {
+	if len(t.Payload) == 0 {
+		return ctxerrors.Errorf(ctxerrors.TypeInvalidRequest, "task %d has no payload", t.ID)
+	}
+
+	if err := json.Unmarshal(t.Payload, target); err != nil {
+		return ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "failed to unmarshal payload of task %d", t.ID)
+	}
+
+	return nil
+}
+
 func (t *Task) GenerateDefaultSchedule(lifetime uint32) {
 	if lifetime == 0 {
 		return
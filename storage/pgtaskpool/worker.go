@@ -6,7 +6,6 @@ import (
 	"github.com/underbek/examples-go/utils"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	ctxerrors "github.com/underbek/examples-go/errors"
 	"github.com/underbek/examples-go/logger"
 	goKitPgx "github.com/underbek/examples-go/storage/pgx"
@@ -18,9 +17,12 @@ type (
 	handler func(context.Context, uint64) error
 
 	Worker interface {
+		Admin
+
 		Create(context.Context, Task) error
 		Cancel(context.Context, uint64) error
 		Run(context.Context, time.Duration, handler) error
+		RunRouter(context.Context, time.Duration, *Router) error
 		Reset(context.Context, Task) error
 	}
 
@@ -43,6 +45,10 @@ func New(logger *logger.Logger, storage goKitPgx.Storage, options ...WorkerOptio
 		opt.apply(&worker.config)
 	}
 
+	if worker.config.LeaseInterval <= 0 {
+		worker.config.LeaseInterval = worker.config.BlockTimeout / 3
+	}
+
 	worker.metrics = newSchedulerMetrics(worker.config.EnableMetrics)
 	newCounterMetrics(worker, worker.config.EnableMetrics)
 
@@ -50,7 +56,7 @@ func New(logger *logger.Logger, storage goKitPgx.Storage, options ...WorkerOptio
 }
 
 func (w *worker) Create(ctx context.Context, task Task) error {
-	isExist, err := w.isTaskExists(ctx, task.TransactionID)
+	isExist, err := w.isTaskExists(ctx, task.Kind, task.TransactionID)
 	if err != nil {
 		return err
 	}
@@ -59,6 +65,7 @@ func (w *worker) Create(ctx context.Context, task Task) error {
 		w.logger.
 			WithCtx(ctx).
 			With("transaction_id", task.TransactionID).
+			With("kind", task.Kind).
 			With("type", task.Type).
 			Warn("task already exists")
 		return nil
@@ -68,11 +75,11 @@ func (w *worker) Create(ctx context.Context, task Task) error {
 		return err
 	}
 
-	return w.createTask(ctx, task)
+	return w.createTask(ctx, w.storage, task, w.firstProcessAt(task))
 }
 
 func (w *worker) Cancel(ctx context.Context, id uint64) error {
-	return w.cancelTask(ctx, id)
+	return w.cancelTask(ctx, w.storage, id)
 }
 
 func (w *worker) Reset(ctx context.Context, task Task) error {
@@ -94,7 +101,7 @@ func (w *worker) Reset(ctx context.Context, task Task) error {
 		cancel()
 	}()
 
-	if err = w.cancelTask(ctx, task.TransactionID); err != nil {
+	if err = w.cancelTask(ctx, tx, task.TransactionID); err != nil {
 		return err
 	}
 
@@ -102,7 +109,7 @@ func (w *worker) Reset(ctx context.Context, task Task) error {
 		return err
 	}
 
-	if err = w.createTask(ctx, task); err != nil {
+	if err = w.createTask(ctx, tx, task, w.firstProcessAt(task)); err != nil {
 		return err
 	}
 
@@ -113,9 +120,45 @@ func (w *worker) Reset(ctx context.Context, task Task) error {
 	return nil
 }
 
+// Run processes the tasks without kind by the handler
 func (w *worker) Run(ctx context.Context, syncInterval time.Duration, handler handler) error {
+	router := NewRouter()
+	router.Handle("", func(ctx context.Context, task Task) error {
+		return handler(ctx, task.TransactionID)
+	})
+
+	return w.RunRouter(ctx, syncInterval, router)
+}
+
+// RunRouter processes the tasks of the router kinds by their handlers
+func (w *worker) RunRouter(ctx context.Context, syncInterval time.Duration, router *Router) error {
 	gr, ctx := errgroup.WithContext(ctx)
 
+	// the slots of the running handlers
+	slots := make(chan struct{}, w.config.Concurrency)
+	wake := make(chan struct{}, 1)
+
+	if w.config.ListenDSN != "" {
+		gr.Go(func() error {
+			w.listen(ctx, wake)
+			return nil
+		})
+	}
+
+	if w.config.AttemptsHistory {
+		gr.Go(func() error {
+			w.cleanAttempts(ctx)
+			return nil
+		})
+	}
+
+	if w.config.RetentionMode != "" || w.config.ArchivePartitions != nil {
+		gr.Go(func() error {
+			w.retain(ctx)
+			return nil
+		})
+	}
+
 	gr.Go(func() error {
 		ticker := time.NewTicker(syncInterval)
 		defer ticker.Stop()
@@ -125,94 +168,112 @@ func (w *worker) Run(ctx context.Context, syncInterval time.Duration, handler ha
 			case <-ctx.Done():
 				return ctx.Err()
 			case <-ticker.C:
-				err := w.execute(ctx, handler)
-				if err != nil {
-					w.logger.
-						WithCtx(ctx).
-						WithError(err).
-						Error("execute failed")
-				}
+			case <-wake:
 			}
+
+			w.drain(ctx, gr, slots, router)
 		}
 	})
 
 	return gr.Wait()
 }
 
-func (w *worker) execute(ctx context.Context, handler handler) error {
-	task, err := w.getTask(ctx)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+// drain executes the batches without waiting for the ticker while the due tasks remain
+func (w *worker) drain(ctx context.Context, gr *errgroup.Group, slots chan struct{}, router *Router) {
+	for {
+		more, err := w.execute(ctx, gr, slots, router)
+		if err != nil {
 			w.logger.
 				WithCtx(ctx).
-				Debug("no tasks")
-			return nil
+				WithError(err).
+				Error("execute failed")
 		}
 
-		w.logger.
-			WithCtx(ctx).
-			WithError(err).
-			Error("getTask returned error")
-		return err
+		if !more {
+			return
+		}
 	}
+}
 
-	task, err = w.processTaskLock(ctx, task)
-	if err != nil {
-		return ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to processTaskLock")
+// execute claims the batch of due tasks for the free slots and runs their handlers in parallel.
+// The batch is limited by both BatchSize and the free Concurrency slots, the slots left without tasks are released.
+// It reports whether the batch is full, so more due tasks may remain.
+func (w *worker) execute(ctx context.Context, gr *errgroup.Group, slots chan struct{}, router *Router) (bool, error) {
+	// waits for at least one free slot
+	select {
+	case <-ctx.Done():
+		return false, nil
+	case slots <- struct{}{}:
 	}
 
-	//The task processing itself
-	task = w.process(ctx, task, handler)
-
-	//Results of the task execution is being saved
-	if err = w.updateTask(ctx, task); err != nil {
-		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to update task")
+	limit := 1
+	for limit < w.config.BatchSize && tryAcquire(slots) {
+		limit++
 	}
 
-	//Releases the task and updates to further processes
-	if err = w.updateTask(ctx, task); err != nil {
-		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to unblock task")
-	}
+	tasks, err := w.claimTasks(ctx, limit, router.kinds())
 
-	return nil
-}
+	// releases the slots left without tasks
+	for i := len(tasks); i < limit; i++ {
+		<-slots
+	}
 
-func (w *worker) processTaskLock(ctx context.Context, task Task) (Task, error) {
-	// Preparing for block task for other instances
-	tx, err := w.storage.Begin(ctx, nil)
 	if err != nil {
-		return task, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to begin transaction")
+		return false, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to claim tasks")
 	}
 
-	defer func() {
-		rCtx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
-
-		if err = tx.Rollback(rCtx); err != nil {
-			w.logger.
-				WithCtx(rCtx).
-				WithError(err).
-				Error("rollback failed")
-		}
+	if len(tasks) == 0 {
+		w.logger.
+			WithCtx(ctx).
+			Debug("no tasks")
 
-		cancel()
-	}()
+		return false, nil
+	}
 
-	// Block task for specific timeout
-	lock := time.Now().Add(w.config.BlockTimeout)
-	task.LockTime = &lock
+	for _, task := range tasks {
+		task := task
+
+		gr.Go(func() error {
+			defer func() {
+				<-slots
+			}()
+
+			//The task processing itself, the lease is extended while the handler runs
+			leaseCtx, stop := w.keepLease(ctx, task)
+			task, attempt := w.process(leaseCtx, task, router.route(task.Kind))
+			stop()
+
+			//Results of the task execution is being saved and the task is released
+			if err := w.saveTask(ctx, task, attempt); errors.Is(err, ErrLeaseLost) {
+				w.logger.
+					WithCtx(ctx).
+					With("task", task).
+					Warn("task result is dropped, the lease is lost")
+			} else if err != nil {
+				w.logger.
+					WithCtx(ctx).
+					WithError(err).
+					With("task", task).
+					Error("failed to update task")
+			}
 
-	if err = w.blockTask(ctx, tx, task); err != nil {
-		return task, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to block task")
+			return nil
+		})
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return task, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to commit transaction")
-	}
+	return len(tasks) == limit, nil
+}
 
-	return task, nil
+func tryAcquire(slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
 }
 
-func (w *worker) process(ctx context.Context, task Task, handler handler) Task {
+func (w *worker) process(ctx context.Context, task Task, rt route) (Task, Attempt) {
 	var err error
 	if task.Trace != nil {
 		ctx = tracing.PutStringTraceInfoIntoContext(ctx, task.Trace.TraceID, task.Trace.SpanID)
@@ -231,7 +292,7 @@ func (w *worker) process(ctx context.Context, task Task, handler handler) Task {
 
 	startTime := time.Now()
 
-	err = w.recoverHandler(ctx, handler, task)
+	err = w.recoverHandler(ctx, rt.handler, task)
 
 	w.logger.
 		WithCtx(ctx).
@@ -248,8 +309,8 @@ func (w *worker) process(ctx context.Context, task Task, handler handler) Task {
 			task.LastErrorMessage = utils.ToPtr(err.Error())
 		}
 
-		attempts := taskAttempts(task)
-		if task.Attempts >= len(attempts) {
+		attempts := taskAttempts(task, rt)
+		if task.Attempts >= len(attempts) || (rt.maxAttempts > 0 && task.Attempts >= rt.maxAttempts) {
 			task.Status = &w.config.FailStatus
 		} else {
 			nextTime := time.Now().Add(time.Second * time.Duration(attempts[task.Attempts]))
@@ -262,5 +323,5 @@ func (w *worker) process(ctx context.Context, task Task, handler handler) Task {
 
 	w.metrics.incTask(task)
 	w.metrics.registerTaskDuration(task, time.Since(startTime).Seconds())
-	return task
+	return task, newAttempt(task, startTime, span.SpanContext().TraceID().String(), err)
 }
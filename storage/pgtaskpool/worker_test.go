@@ -0,0 +1,290 @@
+package pgtaskpool
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"golang.org/x/sync/errgroup"
+)
+
+var claimLimit = regexp.MustCompile(`LIMIT (\d+)`)
+
+// claimStorage returns the batches of the tasks to the claims in turn up to the claim limit and accepts the task updates
+type claimStorage struct {
+	goKitPgx.Storage
+
+	mtx     sync.Mutex
+	batches [][]Task
+	limits  []int
+}
+
+func (s *claimStorage) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	limit, err := strconv.Atoi(claimLimit.FindStringSubmatch(sql)[1])
+	if err != nil {
+		return nil, err
+	}
+
+	s.limits = append(s.limits, limit)
+
+	var tasks []Task
+	if len(s.batches) > 0 {
+		tasks, s.batches = s.batches[0], s.batches[1:]
+	}
+
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	return &taskRows{tasks: tasks, current: -1}, nil
+}
+
+func (s *claimStorage) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (s *claimStorage) claimLimits() []int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]int(nil), s.limits...)
+}
+
+// taskRows scans the tasks by the columns returned by the claim query
+type taskRows struct {
+	pgx.Rows
+
+	tasks   []Task
+	current int
+}
+
+func (r *taskRows) Next() bool {
+	r.current++
+	return r.current < len(r.tasks)
+}
+
+func (r *taskRows) Close() {}
+
+func (r *taskRows) Err() error {
+	return nil
+}
+
+func (r *taskRows) FieldDescriptions() []pgconn.FieldDescription {
+	fields := make([]pgconn.FieldDescription, 0, len(taskColumns))
+	for _, column := range taskColumns {
+		fields = append(fields, pgconn.FieldDescription{Name: column})
+	}
+
+	return fields
+}
+
+func (r *taskRows) Scan(dest ...any) error {
+	if len(dest) == 1 {
+		if scanner, ok := dest[0].(pgx.RowScanner); ok {
+			return scanner.ScanRow(r)
+		}
+	}
+
+	task := reflect.ValueOf(r.tasks[r.current])
+	columns := make(map[string]reflect.Value, task.NumField())
+	for i := 0; i < task.NumField(); i++ {
+		columns[task.Type().Field(i).Tag.Get("db")] = task.Field(i)
+	}
+
+	for i, column := range taskColumns {
+		reflect.ValueOf(dest[i]).Elem().Set(columns[column])
+	}
+
+	return nil
+}
+
+func newClaimWorker(t *testing.T, storage *claimStorage, batchSize, concurrency int) *worker {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	return New(lg, storage, WithBatchSize(batchSize), WithConcurrency(concurrency)).(*worker)
+}
+
+func claimedTasks(ids ...uint64) []Task {
+	tasks := make([]Task, 0, len(ids))
+	for _, id := range ids {
+		tasks = append(tasks, Task{ID: id, TransactionID: id, LeaseToken: 1})
+	}
+
+	return tasks
+}
+
+func TestWorker_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		batchSize   int
+		concurrency int
+		busy        int
+		claimed     []Task
+		limit       int
+		more        bool
+	}{
+		{
+			name:        "full batch",
+			batchSize:   3,
+			concurrency: 5,
+			claimed:     claimedTasks(1, 2, 3),
+			limit:       3,
+			more:        true,
+		},
+		{
+			name:        "partial batch releases unused slots",
+			batchSize:   3,
+			concurrency: 5,
+			claimed:     claimedTasks(1),
+			limit:       3,
+			more:        false,
+		},
+		{
+			name:        "batch capped by concurrency",
+			batchSize:   10,
+			concurrency: 2,
+			claimed:     claimedTasks(1, 2),
+			limit:       2,
+			more:        true,
+		},
+		{
+			name:        "batch capped by free slots",
+			batchSize:   3,
+			concurrency: 5,
+			busy:        3,
+			claimed:     claimedTasks(1, 2),
+			limit:       2,
+			more:        true,
+		},
+		{
+			name:        "no tasks",
+			batchSize:   3,
+			concurrency: 5,
+			limit:       3,
+			more:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &claimStorage{batches: [][]Task{tt.claimed}}
+			w := newClaimWorker(t, storage, tt.batchSize, tt.concurrency)
+
+			slots := make(chan struct{}, w.config.Concurrency)
+			for i := 0; i < tt.busy; i++ {
+				slots <- struct{}{}
+			}
+
+			release := make(chan struct{})
+			router := NewRouter()
+			router.Handle("", func(context.Context, Task) error {
+				<-release
+				return nil
+			})
+
+			var gr errgroup.Group
+			more, err := w.execute(context.Background(), &gr, slots, router)
+			require.NoError(t, err)
+			assert.Equal(t, tt.more, more)
+			assert.Equal(t, []int{tt.limit}, storage.claimLimits())
+
+			// the running handlers hold their slots only
+			assert.Len(t, slots, tt.busy+len(tt.claimed))
+
+			close(release)
+			require.NoError(t, gr.Wait())
+			assert.Len(t, slots, tt.busy)
+		})
+	}
+}
+
+func TestWorker_ExecuteWaitsForSlot(t *testing.T) {
+	storage := &claimStorage{batches: [][]Task{claimedTasks(1)}}
+	w := newClaimWorker(t, storage, 3, 1)
+
+	slots := make(chan struct{}, w.config.Concurrency)
+	slots <- struct{}{}
+
+	router := NewRouter()
+	router.Handle("", func(context.Context, Task) error {
+		return nil
+	})
+
+	var gr errgroup.Group
+	done := make(chan bool)
+	go func() {
+		more, err := w.execute(context.Background(), &gr, slots, router)
+		assert.NoError(t, err)
+		done <- more
+	}()
+
+	time.Sleep(time.Millisecond * 30)
+	assert.Empty(t, storage.claimLimits())
+
+	<-slots
+
+	select {
+	case more := <-done:
+		assert.True(t, more)
+	case <-time.After(time.Second):
+		t.Fatal("execute is not done")
+	}
+
+	require.NoError(t, gr.Wait())
+	assert.Equal(t, []int{1}, storage.claimLimits())
+	assert.Empty(t, slots)
+}
+
+func TestWorker_Drain(t *testing.T) {
+	storage := &claimStorage{batches: [][]Task{
+		claimedTasks(1, 2),
+		claimedTasks(3, 4),
+		claimedTasks(5),
+		claimedTasks(6),
+	}}
+	// the spare slots keep the batch size while the handlers of the previous batch still run
+	w := newClaimWorker(t, storage, 2, 10)
+
+	var (
+		mtx     sync.Mutex
+		handled []uint64
+	)
+
+	router := NewRouter()
+	router.Handle("", func(_ context.Context, task Task) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		handled = append(handled, task.ID)
+		return nil
+	})
+
+	slots := make(chan struct{}, w.config.Concurrency)
+
+	var gr errgroup.Group
+	w.drain(context.Background(), &gr, slots, router)
+	require.NoError(t, gr.Wait())
+
+	// the partial batch stops the drain until the next tick
+	assert.Equal(t, []int{2, 2, 2}, storage.claimLimits())
+
+	sort.Slice(handled, func(i, j int) bool { return handled[i] < handled[j] })
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, handled)
+	assert.Empty(t, slots)
+}
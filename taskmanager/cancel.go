@@ -0,0 +1,113 @@
+package taskmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+// canceledRunTTL is how long the pool remembers a canceled run to drop its tasks still in the transport
+const canceledRunTTL = 24 * time.Hour
+
+var ErrRunCanceled = errors.New("flow run canceled")
+
+type runCancels struct {
+	running  map[string]map[*context.CancelFunc]struct{}
+	canceled map[string]time.Time
+	mtx      sync.Mutex
+}
+
+func newRunCancels() *runCancels {
+	return &runCancels{
+		running:  make(map[string]map[*context.CancelFunc]struct{}),
+		canceled: make(map[string]time.Time),
+	}
+}
+
+// start returns the context of the task canceled with the run, release must be called after the task.
+func (c *runCancels) start(ctx context.Context, runID string) (context.Context, func(), bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.canceled[runID]; ok {
+		return ctx, func() {}, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	tasks, ok := c.running[runID]
+	if !ok {
+		tasks = make(map[*context.CancelFunc]struct{})
+		c.running[runID] = tasks
+	}
+
+	tasks[&cancel] = struct{}{}
+
+	release := func() {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		delete(c.running[runID], &cancel)
+		if len(c.running[runID]) == 0 {
+			delete(c.running, runID)
+		}
+
+		cancel()
+	}
+
+	return ctx, release, true
+}
+
+func (c *runCancels) cancel(runID string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	for id, canceledAt := range c.canceled {
+		if now.Sub(canceledAt) > canceledRunTTL {
+			delete(c.canceled, id)
+		}
+	}
+
+	c.canceled[runID] = now
+
+	for cancel := range c.running[runID] {
+		(*cancel)()
+	}
+}
+
+func (c *runCancels) isCanceled(runID string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.canceled[runID]
+
+	return ok
+}
+
+// checkRunCanceled cancels the run locally when it is canceled in the run store by any replica.
+// The local cancellation is the fast path, the store is asked only for the runs not canceled by this pool.
+func (p *Pool) checkRunCanceled(ctx context.Context, runID string) error {
+	if p.runStore == nil || p.cancels.isCanceled(runID) {
+		return nil
+	}
+
+	run, err := p.runStore.GetRun(ctx, runID)
+	if err != nil {
+		// the run has no transitions before its first task
+		if ctxerrors.ErrorType(err) == ctxerrors.TypeNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	if run.Status == TransitionCanceled {
+		p.cancels.cancel(runID)
+	}
+
+	return nil
+}
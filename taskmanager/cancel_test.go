@@ -0,0 +1,288 @@
+package taskmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+)
+
+type testTaskFunc func(ctx context.Context, meta TaskMeta) (interface{}, error)
+
+func (f testTaskFunc) Run(ctx context.Context, meta TaskMeta) (interface{}, error) {
+	return f(ctx, meta)
+}
+
+type testFuncCreator map[string]testTaskFunc
+
+func (c testFuncCreator) Create(meta TaskMeta) (Task, error) {
+	task, ok := c[meta.TaskID]
+	if !ok {
+		return nil, errors.New("not implemented")
+	}
+
+	return task, nil
+}
+
+// onceFailedCreator fails to create each task the first time
+type onceFailedCreator struct {
+	testFuncCreator
+
+	mtx    sync.Mutex
+	failed map[string]bool
+}
+
+func (c *onceFailedCreator) Create(meta TaskMeta) (Task, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if !c.failed[meta.TaskID] {
+		c.failed[meta.TaskID] = true
+		return nil, errTest
+	}
+
+	return c.testFuncCreator.Create(meta)
+}
+
+func newTestFuncPool(t *testing.T, flow *Flow, creator testFuncCreator) (*Pool, *ChannelTransport) {
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	t.Cleanup(transport.Close)
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	return NewPool(lg, creator, transport, transport, manager, WithRunStore(NewMemoryRunStore())), transport
+}
+
+func TestPool_TaskTimeoutAndPanic(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition,
+		TaskSetting{TaskID: "hung", RunType: SyncTask, Timeout: time.Millisecond * 50},
+		TaskSetting{TaskID: "slow", RunType: SyncTask, Timeout: time.Millisecond * 50},
+		TaskSetting{TaskID: "panic", RunType: SyncTask},
+	)
+
+	pool, _ := newTestFuncPool(t, flow, testFuncCreator{
+		"head": func(context.Context, TaskMeta) (interface{}, error) {
+			return nil, nil
+		},
+		// ignores its context
+		"hung": func(context.Context, TaskMeta) (interface{}, error) {
+			time.Sleep(time.Second)
+			return nil, nil
+		},
+		"slow": func(ctx context.Context, _ TaskMeta) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		"panic": func(context.Context, TaskMeta) (interface{}, error) {
+			panic("test panic")
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	start := time.Now()
+
+	results, err := pool.RunSync(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Less(t, time.Since(start), time.Second)
+
+	for _, result := range results[1:] {
+		assert.Equal(t, FailCondition, result.Condition)
+		assert.Equal(t, ctxerrors.TypeInternal, ctxerrors.ErrorType(result.Err))
+	}
+
+	assert.ErrorIs(t, results[1].Err, context.DeadlineExceeded)
+	assert.ErrorIs(t, results[2].Err, context.DeadlineExceeded)
+	assert.ErrorContains(t, results[3].Err, "task panic panicked: test panic")
+}
+
+func TestPool_CancelRun(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition, TaskSetting{TaskID: "next", RunType: AsyncTask})
+
+	started := make(chan struct{})
+	nextRun := make(chan struct{}, 1)
+
+	pool, _ := newTestFuncPool(t, flow, testFuncCreator{
+		"head": func(ctx context.Context, _ TaskMeta) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+
+			return nil, nil
+		},
+		"next": func(context.Context, TaskMeta) (interface{}, error) {
+			nextRun <- struct{}{}
+			return nil, nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = pool.Run(ctx, 2)
+	}()
+
+	runID, err := pool.Publish(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: AsyncTask})
+	require.NoError(t, err)
+
+	<-started
+	require.NoError(t, pool.CancelRun(ctx, runID))
+
+	// the tasks of the canceled run received later are dropped
+	_, err = pool.Publish(ctx, TaskMeta{FlowID: "flow_1", TaskID: "next", RunType: AsyncTask, RunID: runID})
+	require.NoError(t, err)
+
+	select {
+	case <-nextRun:
+		t.Fatal("task of the canceled run is executed")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// the head returned on the canceled context and its successor is not scheduled
+	history, err := pool.runStore.GetHistory(ctx, runID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, TransitionStarted, history[0].Type)
+	assert.Equal(t, TransitionCanceled, history[1].Type)
+
+	run, err := pool.runStore.GetRun(ctx, runID)
+	require.NoError(t, err)
+	assert.Equal(t, TransitionCanceled, run.Status)
+	assert.Equal(t, "head", run.CurrentTask)
+
+	assert.Error(t, pool.CancelRun(ctx, "unknown"))
+}
+
+func TestPool_RunTaskError(t *testing.T) {
+	manager := NewFlowManager()
+	manager.AddFlow(NewFlow("flow_1"))
+
+	transport := &ackRecorder{ChannelTransport: NewChannelTransport(10)}
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	creator := &onceFailedCreator{
+		testFuncCreator: testFuncCreator{
+			"flaky": func(context.Context, TaskMeta) (interface{}, error) {
+				close(done)
+				return nil, nil
+			},
+		},
+		failed: make(map[string]bool),
+	}
+
+	pool := NewPool(lg, creator, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- pool.Run(ctx, 1)
+	}()
+
+	meta := TaskMeta{FlowID: "flow_1", TaskID: "flaky", RunID: "run", RunType: AsyncTask}
+	require.NoError(t, transport.Publish(ctx, meta))
+
+	// the task failed to create is redelivered to the running pool
+	select {
+	case <-done:
+	case err = <-runErr:
+		t.Fatalf("pool stopped: %v", err)
+	case <-ctx.Done():
+		t.Fatal("task is not run")
+	}
+
+	assert.Eventually(t, func() bool {
+		acks, _ := transport.getAcks()
+		return len(acks) == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	_, noAcks := transport.getAcks()
+	assert.Equal(t, []TaskMeta{meta}, noAcks)
+
+	transport.Close()
+	assert.ErrorIs(t, <-runErr, ErrChannelClosed)
+}
+
+func TestPool_CancelRunReplicas(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition, TaskSetting{TaskID: "next", RunType: AsyncTask})
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	nextRun := make(chan struct{}, 1)
+
+	creator := testFuncCreator{
+		"head": func(context.Context, TaskMeta) (interface{}, error) {
+			close(started)
+			<-finish
+
+			return nil, nil
+		},
+		"next": func(context.Context, TaskMeta) (interface{}, error) {
+			nextRun <- struct{}{}
+			return nil, nil
+		},
+	}
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	transport := NewChannelTransport(10)
+	t.Cleanup(transport.Close)
+
+	// the replicas share the run store only
+	runStore := NewMemoryRunStore()
+	runner := NewPool(lg, creator, transport, transport, manager, WithRunStore(runStore))
+	canceler := NewPool(lg, creator, transport, transport, manager, WithRunStore(runStore))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = runner.Run(ctx, 2)
+	}()
+
+	runID, err := runner.Publish(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: AsyncTask})
+	require.NoError(t, err)
+
+	<-started
+	require.NoError(t, canceler.CancelRun(ctx, runID))
+	close(finish)
+
+	// the running replica does not schedule the successor and drops the tasks of the run
+	_, err = runner.Publish(ctx, TaskMeta{FlowID: "flow_1", TaskID: "next", RunType: AsyncTask, RunID: runID})
+	require.NoError(t, err)
+
+	select {
+	case <-nextRun:
+		t.Fatal("task of the canceled run is executed")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	history, err := runStore.GetHistory(ctx, runID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, TransitionStarted, history[0].Type)
+	assert.Equal(t, TransitionCanceled, history[1].Type)
+}
@@ -0,0 +1,113 @@
+package taskmanager
+
+import (
+	"encoding/json"
+	"reflect"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts the task results to the representation surviving the transports and back.
+type Codec interface {
+	// Encode converts the result of a task before it is passed to the next tasks
+	Encode(value interface{}) (interface{}, error)
+	// Decode converts the previous result received by a task into the target pointer
+	Decode(value interface{}, target interface{}) error
+}
+
+var (
+	_ Codec = JSONCodec{}
+	_ Codec = ProtoCodec{}
+)
+
+// JSONCodec keeps the results as is and decodes them through their JSON representation.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (JSONCodec) Decode(value interface{}, target interface{}) error {
+	data, ok := rawJSON(value)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, "failed to marshal previous result")
+		}
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, "failed to unmarshal previous result")
+	}
+
+	return nil
+}
+
+// ProtoCodec passes the proto messages in the protojson representation.
+// The result is decoded into the target which is not a proto message through its JSON representation.
+type ProtoCodec struct{}
+
+func (ProtoCodec) Encode(value interface{}) (interface{}, error) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return nil, ctxerrors.Errorf(ctxerrors.TypeInternal, "result %T is not a proto message", value)
+	}
+
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to marshal result")
+	}
+
+	return json.RawMessage(data), nil
+}
+
+func (ProtoCodec) Decode(value interface{}, target interface{}) error {
+	// a pointer to a nil message is allocated
+	rv := reflect.ValueOf(target)
+	if rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Ptr {
+		if rv.Elem().IsNil() {
+			rv.Elem().Set(reflect.New(rv.Elem().Type().Elem()))
+		}
+
+		target = rv.Elem().Interface()
+	}
+
+	msg, ok := target.(proto.Message)
+	if !ok {
+		return JSONCodec{}.Decode(value, target)
+	}
+
+	if value, ok := value.(proto.Message); ok {
+		proto.Reset(msg)
+		proto.Merge(msg, value)
+
+		return nil
+	}
+
+	data, ok := rawJSON(value)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, "failed to marshal previous result")
+		}
+	}
+
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, "failed to unmarshal previous result")
+	}
+
+	return nil
+}
+
+func rawJSON(value interface{}) ([]byte, bool) {
+	switch value := value.(type) {
+	case json.RawMessage:
+		return value, true
+	case []byte:
+		return value, true
+	default:
+		return nil, false
+	}
+}
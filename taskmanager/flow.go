@@ -1,5 +1,7 @@
 package taskmanager
 
+import "time"
+
 type ConditionType string
 
 const (
@@ -14,20 +16,27 @@ type taskKey struct {
 }
 
 type TaskSetting struct {
-	TaskID     string
-	RunType    TaskRunType
-	RetryCount int
+	TaskID      string       `json:"task_id"`
+	RunType     TaskRunType  `json:"run_type"`
+	RetryCount  int          `json:"retry_count,omitempty"`
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+	// Timeout limits a single run of the task, a timed out task fails
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Compensator is the task rolling this one back, see Compensation
+	Compensator *TaskSetting `json:"compensator,omitempty"`
 }
 
 type Flow struct {
 	flowID string
 	tasks  map[taskKey][]TaskSetting
+	joins  map[taskKey][]Join
 }
 
 func NewFlow(flowID string) *Flow {
 	return &Flow{
 		flowID: flowID,
 		tasks:  make(map[taskKey][]TaskSetting),
+		joins:  make(map[taskKey][]Join),
 	}
 }
 
@@ -69,12 +78,60 @@ func (f *Flow) GetTasks(taskID string, conditionType ConditionType) []TaskMeta {
 	metas := make([]TaskMeta, 0, len(settings))
 	for _, setting := range settings {
 		metas = append(metas, TaskMeta{
-			TaskID:     setting.TaskID,
-			FlowID:     f.flowID,
-			RunType:    setting.RunType,
-			RetryCount: setting.RetryCount,
+			TaskID:      setting.TaskID,
+			FlowID:      f.flowID,
+			RunType:     setting.RunType,
+			RetryCount:  setting.RetryCount,
+			RetryPolicy: setting.RetryPolicy,
+			Timeout:     setting.Timeout,
+			Compensator: setting.Compensator,
 		})
 	}
 
 	return metas
 }
+
+// AddJoin adds the task waiting for several parent tasks.
+func (f *Flow) AddJoin(join Join) {
+	conditions := []ConditionType{join.Condition}
+	if join.Condition == AllCondition {
+		conditions = []ConditionType{SuccessCondition, FailCondition}
+	}
+
+	for _, parentID := range join.Parents {
+		for _, condition := range conditions {
+			key := taskKey{
+				taskID:        parentID,
+				conditionType: condition,
+			}
+
+			f.joins[key] = append(f.joins[key], join)
+		}
+	}
+}
+
+func (f *Flow) GetJoins(taskID string, conditionType ConditionType) []Join {
+	return f.joins[taskKey{
+		taskID:        taskID,
+		conditionType: conditionType,
+	}]
+}
+
+func (f *Flow) newJoinMeta(join Join, arrivals map[string]JoinResult) TaskMeta {
+	results := make(map[string]interface{}, len(arrivals))
+	for parentID, arrival := range arrivals {
+		results[parentID] = arrival.Result
+	}
+
+	return TaskMeta{
+		TaskID:         join.Task.TaskID,
+		FlowID:         f.flowID,
+		RunType:        join.Task.RunType,
+		RetryCount:     join.Task.RetryCount,
+		RetryPolicy:    join.Task.RetryPolicy,
+		Timeout:        join.Task.Timeout,
+		Compensator:    join.Task.Compensator,
+		PreviousResult: results,
+		Compensations:  mergeCompensations(join.Parents, arrivals),
+	}
+}
@@ -1,9 +1,19 @@
 package taskmanager
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
 
+var ErrFlowNotFound = errors.New("flow not found")
+
+// FlowManager is safe for concurrent use, so the flows may be reloaded while the pool is running.
+// The tasks already taken by the workers finish with the flow they got.
+// The tasks of a flow removed by the reload which are still in the transport are dropped by the pool.
 type FlowManager struct {
 	flows map[string]*Flow
+	mtx   sync.RWMutex
 }
 
 func NewFlowManager() *FlowManager {
@@ -13,15 +23,58 @@ func NewFlowManager() *FlowManager {
 }
 
 func (m *FlowManager) AddFlow(flows ...*Flow) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
 	for _, flow := range flows {
 		m.flows[flow.flowID] = flow
 	}
 }
 
+// ReplaceFlows atomically replaces all the flows of the manager.
+func (m *FlowManager) ReplaceFlows(flows ...*Flow) {
+	replaced := make(map[string]*Flow, len(flows))
+	for _, flow := range flows {
+		replaced[flow.flowID] = flow
+	}
+
+	m.mtx.Lock()
+	m.flows = replaced
+	m.mtx.Unlock()
+}
+
+// Load replaces the flows with the ones from the YAML or JSON definition.
+// The current flows are kept if the definition is invalid.
+func (m *FlowManager) Load(data []byte, creator TaskCreator) error {
+	flows, err := ParseFlows(data, creator)
+	if err != nil {
+		return err
+	}
+
+	m.ReplaceFlows(flows...)
+
+	return nil
+}
+
+// LoadFile is Load from the definition file.
+func (m *FlowManager) LoadFile(path string, creator TaskCreator) error {
+	flows, err := ParseFlowsFile(path, creator)
+	if err != nil {
+		return err
+	}
+
+	m.ReplaceFlows(flows...)
+
+	return nil
+}
+
 func (m *FlowManager) GetFlow(flowID string) (*Flow, error) {
+	m.mtx.RLock()
 	flow, ok := m.flows[flowID]
+	m.mtx.RUnlock()
+
 	if !ok {
-		return nil, fmt.Errorf("flow with id %s is not exists", flowID)
+		return nil, fmt.Errorf("flow with id %s is not exists: %w", flowID, ErrFlowNotFound)
 	}
 
 	return flow, nil
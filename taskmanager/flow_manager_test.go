@@ -21,5 +21,6 @@ func TestFlowManager_GetFlow(t *testing.T) {
 
 	flow, err = manager.GetFlow("3")
 	assert.Errorf(t, err, "flow with id 3 is not exists")
+	assert.ErrorIs(t, err, ErrFlowNotFound)
 	assert.Nil(t, flow)
 }
@@ -0,0 +1,157 @@
+package taskmanager
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultJoinTTL is how long the join state is kept after its last arrival.
+// The completed join is remembered within the TTL, so its redelivered parents do not fire it again.
+const DefaultJoinTTL = 24 * time.Hour
+
+// Join is a task that runs once Required of its Parents finish with the Condition.
+// The task gets the results of the arrived parents merged into PreviousResult keyed by the parent task id.
+type Join struct {
+	Parents   []string
+	Condition ConditionType
+	// Required is the number of parents to wait for, all parents by default
+	Required int
+	Task     TaskSetting
+}
+
+func (j Join) required() int {
+	if j.Required <= 0 || j.Required > len(j.Parents) {
+		return len(j.Parents)
+	}
+
+	return j.Required
+}
+
+type JoinArrival struct {
+	RunID    string
+	JoinID   string
+	ParentID string
+	Result   interface{}
+	// Compensations are the steps the parent passes to the join
+	Compensations []Compensation
+	Required      int
+	Total         int
+}
+
+// JoinResult is what the parent passed to the join on its arrival
+type JoinResult struct {
+	Result        interface{}
+	Compensations []Compensation
+}
+
+// JoinStore keeps the state of joins per flow run.
+// Arrive records the parent result and reports the results arrived so far keyed by the parent id and
+// whether the join is ready. A join is ready exactly once per run, repeated arrivals of
+// the same parent are ignored, also after all the parents arrived, until the state expires.
+type JoinStore interface {
+	Arrive(context.Context, JoinArrival) (map[string]JoinResult, bool, error)
+}
+
+type joinKey struct {
+	runID  string
+	joinID string
+}
+
+type joinState struct {
+	results map[string]JoinResult
+	fired   bool
+	// completed join has got all the parents, its results are released
+	completed bool
+	updatedAt time.Time
+}
+
+// MemoryJoinStore keeps the joins state in memory of the process.
+// It fits pools sharing one process, replicas need a shared store.
+type MemoryJoinStore struct {
+	joins map[joinKey]*joinState
+	ttl   time.Duration
+	mtx   sync.Mutex
+}
+
+type MemoryJoinStoreOption func(*MemoryJoinStore)
+
+// WithMemoryJoinTTL Option to determine how long the join state is kept after its last arrival, DefaultJoinTTL by default
+func WithMemoryJoinTTL(ttl time.Duration) MemoryJoinStoreOption {
+	return func(s *MemoryJoinStore) {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+	}
+}
+
+func NewMemoryJoinStore(opts ...MemoryJoinStoreOption) *MemoryJoinStore {
+	s := &MemoryJoinStore{
+		joins: make(map[joinKey]*joinState),
+		ttl:   DefaultJoinTTL,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *MemoryJoinStore) Arrive(_ context.Context, arrival JoinArrival) (map[string]JoinResult, bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := time.Now()
+	for key, state := range s.joins {
+		if now.Sub(state.updatedAt) > s.ttl {
+			delete(s.joins, key)
+		}
+	}
+
+	key := joinKey{
+		runID:  arrival.RunID,
+		joinID: arrival.JoinID,
+	}
+
+	state, ok := s.joins[key]
+	if !ok {
+		state = &joinState{
+			results: make(map[string]JoinResult),
+		}
+		s.joins[key] = state
+	}
+
+	state.updatedAt = now
+
+	// the redelivered parent of the completed join
+	if state.completed {
+		return nil, false, nil
+	}
+
+	if _, ok = state.results[arrival.ParentID]; !ok {
+		state.results[arrival.ParentID] = JoinResult{
+			Result:        arrival.Result,
+			Compensations: arrival.Compensations,
+		}
+	}
+
+	results := make(map[string]JoinResult, len(state.results))
+	for parentID, result := range state.results {
+		results[parentID] = result
+	}
+
+	ready := !state.fired && len(state.results) >= arrival.Required
+	if ready {
+		state.fired = true
+	}
+
+	// nothing else can arrive, the state is kept as the completed marker only
+	if len(state.results) >= arrival.Total {
+		state.fired = true
+		state.completed = true
+		state.results = nil
+	}
+
+	return results, ready, nil
+}
@@ -0,0 +1,290 @@
+package taskmanager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+)
+
+type joinTestCreator struct {
+	mtx  sync.Mutex
+	runs []TaskMeta
+}
+
+func (c *joinTestCreator) Create(TaskMeta) (Task, error) {
+	return c, nil
+}
+
+func (c *joinTestCreator) Run(_ context.Context, meta TaskMeta) (interface{}, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.runs = append(c.runs, meta)
+
+	if meta.TaskID == "failed" {
+		return nil, errTest
+	}
+
+	return meta.TaskID, nil
+}
+
+func (c *joinTestCreator) getRuns(taskID string) []TaskMeta {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var runs []TaskMeta
+	for _, run := range c.runs {
+		if run.TaskID == taskID {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs
+}
+
+func TestMemoryJoinStore_Arrive(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryJoinStore()
+
+	arrival := JoinArrival{RunID: "run", JoinID: "join", Required: 2, Total: 3}
+
+	compensations := []Compensation{{StepID: "step", Compensator: TaskSetting{TaskID: "compensator"}}}
+
+	arrival.ParentID, arrival.Result, arrival.Compensations = "1", "result_1", compensations
+	results, ready, err := store.Arrive(ctx, arrival)
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, map[string]JoinResult{"1": {Result: "result_1", Compensations: compensations}}, results)
+
+	// repeated arrival is ignored
+	_, ready, err = store.Arrive(ctx, arrival)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	// another run does not share the state
+	_, ready, err = store.Arrive(ctx, JoinArrival{RunID: "other", JoinID: "join", ParentID: "2", Required: 2, Total: 3})
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	arrival.ParentID, arrival.Result, arrival.Compensations = "2", "result_2", nil
+	results, ready, err = store.Arrive(ctx, arrival)
+	require.NoError(t, err)
+	assert.True(t, ready)
+	assert.Equal(t, map[string]JoinResult{
+		"1": {Result: "result_1", Compensations: compensations},
+		"2": {Result: "result_2"},
+	}, results)
+
+	// the join is ready once
+	arrival.ParentID, arrival.Result = "3", "result_3"
+	_, ready, err = store.Arrive(ctx, arrival)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	// the results are released after the last parent, the completed marker is kept
+	state := store.joins[joinKey{runID: "run", joinID: "join"}]
+	require.NotNil(t, state)
+	assert.True(t, state.completed)
+	assert.Nil(t, state.results)
+}
+
+func TestMemoryJoinStore_Redelivery(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryJoinStore(WithMemoryJoinTTL(time.Hour))
+
+	arrival := JoinArrival{RunID: "run", JoinID: "join", Required: 1, Total: 2}
+
+	arrival.ParentID = "1"
+	_, ready, err := store.Arrive(ctx, arrival)
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	arrival.ParentID = "2"
+	_, ready, err = store.Arrive(ctx, arrival)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	// the redelivered parents of the completed join do not fire it again
+	for _, parentID := range []string{"1", "2"} {
+		arrival.ParentID = parentID
+		results, ready, err := store.Arrive(ctx, arrival)
+		require.NoError(t, err)
+		assert.False(t, ready)
+		assert.Empty(t, results)
+	}
+
+	// the expired state is dropped by the next arrival
+	store.joins[joinKey{runID: "run", joinID: "join"}].updatedAt = time.Now().Add(-time.Hour * 2)
+
+	_, _, err = store.Arrive(ctx, JoinArrival{RunID: "other", JoinID: "join", ParentID: "1", Required: 2, Total: 2})
+	require.NoError(t, err)
+	assert.Len(t, store.joins, 1)
+	assert.NotContains(t, store.joins, joinKey{runID: "run", joinID: "join"})
+}
+
+func TestFlow_GetJoins(t *testing.T) {
+	flow := NewFlow("test")
+	join := Join{
+		Parents:   []string{"1", "2"},
+		Condition: AllCondition,
+		Task:      TaskSetting{TaskID: "join", RunType: SyncTask},
+	}
+	flow.AddJoin(join)
+
+	assert.Equal(t, []Join{join}, flow.GetJoins("1", SuccessCondition))
+	assert.Equal(t, []Join{join}, flow.GetJoins("2", FailCondition))
+	assert.Empty(t, flow.GetJoins("join", SuccessCondition))
+	assert.Empty(t, flow.GetTasks("1", SuccessCondition))
+}
+
+func TestPool_RunAsyncJoin(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition,
+		TaskSetting{TaskID: "1", RunType: AsyncTask},
+		TaskSetting{TaskID: "2", RunType: AsyncTask},
+		TaskSetting{TaskID: "failed", RunType: AsyncTask},
+	)
+	flow.AddJoin(Join{
+		Parents:   []string{"1", "2", "failed"},
+		Condition: AllCondition,
+		Task:      TaskSetting{TaskID: "join_all", RunType: AsyncTask},
+	})
+	flow.AddJoin(Join{
+		Parents:   []string{"1", "2"},
+		Condition: SuccessCondition,
+		Required:  1,
+		Task:      TaskSetting{TaskID: "join_any", RunType: AsyncTask},
+	})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	creator := &joinTestCreator{}
+	transport := NewChannelTransport(10)
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, creator, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = pool.Run(ctx, 4)
+	}()
+
+	require.NoError(t, transport.Publish(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: AsyncTask}))
+	require.NoError(t, transport.Publish(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: AsyncTask}))
+
+	assert.Eventually(t, func() bool {
+		return len(creator.getRuns("join_all")) == 2 && len(creator.getRuns("join_any")) == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	// a little time for unexpected extra runs
+	time.Sleep(time.Millisecond * 50)
+	transport.Close()
+
+	joinAll := creator.getRuns("join_all")
+	require.Len(t, joinAll, 2)
+	assert.NotEqual(t, joinAll[0].RunID, joinAll[1].RunID)
+
+	for _, meta := range joinAll {
+		assert.Equal(t, map[string]interface{}{"1": "1", "2": "2", "failed": nil}, meta.PreviousResult)
+	}
+
+	joinAny := creator.getRuns("join_any")
+	require.Len(t, joinAny, 2)
+
+	for _, meta := range joinAny {
+		assert.Len(t, meta.PreviousResult, 1)
+	}
+}
+
+func TestPool_RunSyncJoin(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition,
+		TaskSetting{TaskID: "1", RunType: SyncTask},
+		TaskSetting{TaskID: "2", RunType: SyncTask},
+	)
+	flow.AddJoin(Join{
+		Parents:   []string{"1", "2"},
+		Condition: SuccessCondition,
+		Task:      TaskSetting{TaskID: "join", RunType: SyncTask},
+	})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, &joinTestCreator{}, transport, transport, manager)
+
+	results, err := pool.RunSync(context.Background(), TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, "join", results[3].Meta.TaskID)
+	assert.Equal(t, map[string]interface{}{"1": "1", "2": "2"}, results[3].Meta.PreviousResult)
+}
+
+func TestPool_RunJoinRedelivered(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddJoin(Join{
+		Parents:   []string{"1", "2"},
+		Condition: SuccessCondition,
+		Required:  1,
+		Task:      TaskSetting{TaskID: "join", RunType: AsyncTask},
+	})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	creator := &joinTestCreator{}
+	transport := NewChannelTransport(10)
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, creator, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = pool.Run(ctx, 1)
+	}()
+
+	parent := func(taskID string) TaskMeta {
+		return TaskMeta{FlowID: "flow_1", TaskID: taskID, RunID: "run", RunType: AsyncTask}
+	}
+
+	require.NoError(t, transport.Publish(ctx, parent("1")))
+	require.NoError(t, transport.Publish(ctx, parent("2")))
+
+	assert.Eventually(t, func() bool {
+		return len(creator.getRuns("2")) == 1 && len(creator.getRuns("join")) == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	// the parent is delivered once more after the join completed
+	require.NoError(t, transport.Publish(ctx, parent("1")))
+
+	assert.Eventually(t, func() bool {
+		return len(creator.getRuns("1")) == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	// a little time for unexpected extra runs
+	time.Sleep(time.Millisecond * 50)
+	transport.Close()
+
+	assert.Len(t, creator.getRuns("join"), 1)
+}
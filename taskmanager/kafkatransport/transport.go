@@ -0,0 +1,220 @@
+package kafkatransport
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/taskmanager"
+	kafkaTransport "github.com/underbek/examples-go/transport/kafka"
+)
+
+var _ taskmanager.Publisher = (*Transport)(nil)
+var _ taskmanager.Subscriber = (*Transport)(nil)
+
+const defaultRetryInterval = time.Second
+
+// Transport is a taskmanager.Publisher and taskmanager.Subscriber on top of kafka.
+// TaskMeta is sent as a json message value, trace and logger meta are carried in the message headers.
+//
+// The consumer handles messages one by one, so a message stays in flight until the task is acked:
+// Ack commits the message, NoAck publishes the task to the end of the topic and commits the original one.
+// The postponed task is published to the end of the topic the same way until it is due, the consumer never waits for it.
+// The failed publish is retried until it succeeds or the consuming stops, the original message
+// is not committed meanwhile, so the task is never lost.
+type Transport struct {
+	logger        *logger.Logger
+	producer      kafkaTransport.Producer
+	consumer      kafkaTransport.Consumer
+	topic         string
+	retryInterval time.Duration
+
+	deliveries chan taskmanager.TaskMeta
+	start      sync.Once
+	done       chan struct{}
+	err        error
+}
+
+type Option func(*Transport)
+
+// WithTopic Defines the topic of published messages, required when the producer allows manual topics
+func WithTopic(topic string) Option {
+	return func(t *Transport) {
+		t.topic = topic
+	}
+}
+
+// WithRetryInterval Defines the delay between the publish retries of the republished task, a second by default
+func WithRetryInterval(interval time.Duration) Option {
+	return func(t *Transport) {
+		if interval > 0 {
+			t.retryInterval = interval
+		}
+	}
+}
+
+func New(
+	logger *logger.Logger,
+	producer kafkaTransport.Producer,
+	consumer kafkaTransport.Consumer,
+	opts ...Option,
+) *Transport {
+	t := &Transport{
+		logger:        logger.Named("task_manager_kafka_transport"),
+		producer:      producer,
+		consumer:      consumer,
+		retryInterval: defaultRetryInterval,
+		deliveries:    make(chan taskmanager.TaskMeta),
+		done:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+func (t *Transport) Publish(ctx context.Context, metas ...taskmanager.TaskMeta) error {
+	for _, meta := range metas {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to marshal task meta")
+		}
+
+		// the producer injects the trace and the logger meta of the context into the headers
+		msgCtx := taskmanager.PutTaskTraceIntoContext(ctx, meta.Trace)
+
+		err = t.producer.Publish(msgCtx, kafka.Message{
+			Topic: t.topic,
+			Value: data,
+		})
+		if err != nil {
+			return ctxerrors.Wrap(err, ctxerrors.TypeExternal, "failed to publish task")
+		}
+	}
+
+	return nil
+}
+
+// Subscribe starts consuming with the context of the first call and waits for the next task.
+func (t *Transport) Subscribe(ctx context.Context) (taskmanager.TaskMeta, error) {
+	t.start.Do(func() {
+		go func() {
+			t.err = t.consumer.Consume(ctx, t.handle)
+			close(t.done)
+		}()
+	})
+
+	select {
+	case <-ctx.Done():
+		return taskmanager.TaskMeta{}, ctx.Err()
+	case <-t.done:
+		return taskmanager.TaskMeta{}, ctxerrors.Wrap(t.err, ctxerrors.TypeExternal, "consume stopped")
+	case meta := <-t.deliveries:
+		return meta, nil
+	}
+}
+
+func (t *Transport) Ack(ctx context.Context, meta taskmanager.TaskMeta) {
+	t.acknowledge(ctx, meta, true)
+}
+
+func (t *Transport) NoAck(ctx context.Context, meta taskmanager.TaskMeta) {
+	t.acknowledge(ctx, meta, false)
+}
+
+func (t *Transport) acknowledge(ctx context.Context, meta taskmanager.TaskMeta, ack bool) {
+	acked, ok := meta.Delivery.(chan bool)
+	if !ok {
+		t.logger.WithCtx(ctx).
+			With("task_meta", meta).
+			Error("acknowledge of task not received from kafka transport")
+
+		return
+	}
+
+	select {
+	case acked <- ack:
+	default:
+		t.logger.WithCtx(ctx).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Error("task is already acknowledged")
+	}
+}
+
+func (t *Transport) handle(ctx context.Context, msg kafka.Message) error {
+	var meta taskmanager.TaskMeta
+	if err := json.Unmarshal(msg.Value, &meta); err != nil {
+		// committing the broken message, otherwise it blocks the partition forever
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("topic", msg.Topic).
+			With("partition", msg.Partition).
+			With("offset", msg.Offset).
+			Error("failed to unmarshal task meta, message skipped")
+
+		return nil
+	}
+
+	// the trace of the producer is kept, the consumer one continues the tasks published without it
+	if meta.Trace == nil {
+		meta.Trace = taskmanager.NewTaskTrace(ctx)
+	}
+
+	if meta.NotBefore != nil && time.Until(*meta.NotBefore) > 0 {
+		// kafka has no delayed delivery, the postponed task goes to the end of the topic until it is due,
+		// so it does not hold its partition
+		return t.republish(ctx, meta)
+	}
+
+	acked := make(chan bool, 1)
+	meta.Delivery = acked
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case t.deliveries <- meta:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case ok := <-acked:
+		if ok {
+			return nil
+		}
+	}
+
+	meta.Delivery = nil
+
+	return t.republish(ctx, meta)
+}
+
+// republish publishes the not acknowledged or postponed task until it succeeds, the original message is committed after it.
+// The consuming stopped meanwhile leaves the message uncommitted, so it is redelivered.
+func (t *Transport) republish(ctx context.Context, meta taskmanager.TaskMeta) error {
+	for {
+		err := t.Publish(ctx, meta)
+		if err == nil {
+			return nil
+		}
+
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Error("failed to republish task, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctxerrors.Wrap(ctx.Err(), ctxerrors.TypeExternal, "task is not republished")
+		case <-time.After(t.retryInterval):
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package kafkatransport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/taskmanager"
+	kafkaTransport "github.com/underbek/examples-go/transport/kafka"
+)
+
+type testBroker struct {
+	mtx       sync.Mutex
+	messages  chan kafka.Message
+	committed []kafka.Message
+	// failures is the number of the next publishes to fail
+	failures  int
+	publishes int
+}
+
+func newTestBroker() *testBroker {
+	return &testBroker{messages: make(chan kafka.Message, 10)}
+}
+
+func (b *testBroker) Publish(_ context.Context, msg kafka.Message) error {
+	b.mtx.Lock()
+	b.publishes++
+	if b.failures > 0 {
+		b.failures--
+		b.mtx.Unlock()
+
+		return errTestPublish
+	}
+	b.mtx.Unlock()
+
+	b.messages <- msg
+	return nil
+}
+
+func (b *testBroker) failNext(n int) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.failures = n
+}
+
+func (b *testBroker) publishCount() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.publishes
+}
+
+func (b *testBroker) Consume(ctx context.Context, handler kafkaTransport.Handler) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-b.messages:
+			if err := handler(ctx, msg); err != nil {
+				continue
+			}
+
+			b.mtx.Lock()
+			b.committed = append(b.committed, msg)
+			b.mtx.Unlock()
+		}
+	}
+}
+
+func (b *testBroker) Close() error {
+	return nil
+}
+
+func (b *testBroker) committedCount() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return len(b.committed)
+}
+
+func TestTransport_AckNoAck(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	broker := newTestBroker()
+	tr := New(lg, broker, broker)
+
+	err = tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow", RunType: taskmanager.AsyncTask})
+	require.NoError(t, err)
+
+	meta, err := tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", meta.TaskID)
+	assert.Equal(t, "flow", meta.FlowID)
+
+	tr.NoAck(ctx, meta)
+
+	redelivered, err := tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", redelivered.TaskID)
+	assert.Equal(t, 1, broker.committedCount())
+
+	tr.Ack(ctx, redelivered)
+
+	assert.Eventually(t, func() bool {
+		return broker.committedCount() == 2
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestTransport_NoAckPublishRetried(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	broker := newTestBroker()
+	tr := New(lg, broker, broker, WithRetryInterval(time.Millisecond*10))
+
+	err = tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow", RunType: taskmanager.AsyncTask})
+	require.NoError(t, err)
+
+	meta, err := tr.Subscribe(ctx)
+	require.NoError(t, err)
+
+	broker.failNext(2)
+	tr.NoAck(ctx, meta)
+
+	// the original message is committed only after the task is published again
+	redelivered, err := tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", redelivered.TaskID)
+	assert.Equal(t, 4, broker.publishCount())
+	assert.Equal(t, 1, broker.committedCount())
+}
+
+func TestTransport_NoAckStopped(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	broker := newTestBroker()
+	tr := New(lg, broker, broker, WithRetryInterval(time.Millisecond*10))
+
+	err = tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow", RunType: taskmanager.AsyncTask})
+	require.NoError(t, err)
+
+	consumeCtx, stop := context.WithCancel(ctx)
+
+	meta, err := tr.Subscribe(consumeCtx)
+	require.NoError(t, err)
+
+	broker.failNext(1000)
+	tr.NoAck(ctx, meta)
+
+	assert.Eventually(t, func() bool {
+		return broker.publishCount() > 3
+	}, time.Second, time.Millisecond*10)
+
+	// the consuming stopped while the publish fails leaves the message uncommitted
+	stop()
+
+	_, err = tr.Subscribe(ctx)
+	require.Error(t, err)
+	assert.Zero(t, broker.committedCount())
+}
+
+func TestTransport_NotBefore(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	broker := newTestBroker()
+	tr := New(lg, broker, broker)
+
+	notBefore := time.Now().Add(time.Millisecond * 200)
+	err = tr.Publish(ctx,
+		taskmanager.TaskMeta{TaskID: "postponed", FlowID: "flow", RunType: taskmanager.AsyncTask, NotBefore: &notBefore},
+		taskmanager.TaskMeta{TaskID: "due", FlowID: "flow", RunType: taskmanager.AsyncTask},
+	)
+	require.NoError(t, err)
+
+	// the postponed task does not hold the partition
+	meta, err := tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "due", meta.TaskID)
+	assert.True(t, time.Now().Before(notBefore))
+	assert.GreaterOrEqual(t, broker.committedCount(), 1)
+
+	tr.Ack(ctx, meta)
+
+	meta, err = tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "postponed", meta.TaskID)
+	assert.False(t, time.Now().Before(notBefore))
+}
+
+func TestTransport_Trace(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	broker := newTestBroker()
+	tr := New(lg, broker, broker)
+
+	producerTrace := &taskmanager.TaskTrace{
+		TraceID: [16]byte{1},
+		SpanID:  [8]byte{2},
+		Meta:    map[string]string{"request_id": "producer"},
+	}
+
+	err = tr.Publish(ctx,
+		taskmanager.TaskMeta{TaskID: "traced", FlowID: "flow", RunType: taskmanager.AsyncTask, Trace: producerTrace},
+		taskmanager.TaskMeta{TaskID: "untraced", FlowID: "flow", RunType: taskmanager.AsyncTask},
+	)
+	require.NoError(t, err)
+
+	consumeCtx := logger.AddCtxMetaValues(ctx, map[string]string{"request_id": "consumer"})
+
+	meta, err := tr.Subscribe(consumeCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "traced", meta.TaskID)
+	assert.Equal(t, producerTrace, meta.Trace)
+
+	tr.Ack(ctx, meta)
+
+	meta, err = tr.Subscribe(consumeCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "untraced", meta.TaskID)
+	require.NotNil(t, meta.Trace)
+	assert.Equal(t, "consumer", meta.Trace.Meta["request_id"])
+}
+
+func TestTransport_Pool(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	flow := taskmanager.NewFlow("flow")
+	flow.AddCondition("head", taskmanager.SuccessCondition, taskmanager.TaskSetting{
+		TaskID:  "next",
+		RunType: taskmanager.AsyncTask,
+	})
+
+	manager := taskmanager.NewFlowManager()
+	manager.AddFlow(flow)
+
+	creator := &testCreator{done: make(chan taskmanager.TaskMeta, 2)}
+
+	broker := newTestBroker()
+	tr := New(lg, broker, broker)
+
+	pool := taskmanager.NewPool(lg, creator, tr, tr, manager)
+
+	go func() {
+		_ = pool.Run(ctx, 2)
+	}()
+
+	err = tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "head", FlowID: "flow", RunType: taskmanager.AsyncTask})
+	require.NoError(t, err)
+
+	assert.Equal(t, "head", (<-creator.done).TaskID)
+
+	next := <-creator.done
+	assert.Equal(t, "next", next.TaskID)
+	assert.Equal(t, "head", next.PreviousResult)
+
+	assert.Eventually(t, func() bool {
+		return broker.committedCount() == 2
+	}, time.Second, time.Millisecond*10)
+}
+
+var errTestPublish = errors.New("test publish error")
+
+type testCreator struct {
+	done chan taskmanager.TaskMeta
+}
+
+func (c *testCreator) Create(taskmanager.TaskMeta) (taskmanager.Task, error) {
+	return c, nil
+}
+
+func (c *testCreator) Run(_ context.Context, meta taskmanager.TaskMeta) (interface{}, error) {
+	c.done <- meta
+	return meta.TaskID, nil
+}
@@ -0,0 +1,473 @@
+package taskmanager
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FlowsDefinition is a document with declarative flows, YAML or JSON.
+type FlowsDefinition struct {
+	Flows []FlowDefinition `json:"flows" yaml:"flows"`
+}
+
+type FlowDefinition struct {
+	FlowID string `json:"flow_id" yaml:"flow_id"`
+	// Head is the task starting the flow, every other task must be reachable from it
+	Head  string           `json:"head" yaml:"head"`
+	Tasks []TaskDefinition `json:"tasks" yaml:"tasks"`
+	Joins []JoinDefinition `json:"joins,omitempty" yaml:"joins,omitempty"`
+}
+
+type TaskDefinition struct {
+	TaskID     string      `json:"task_id" yaml:"task_id"`
+	RunType    TaskRunType `json:"run_type" yaml:"run_type"`
+	RetryCount int         `json:"retry_count,omitempty" yaml:"retry_count,omitempty"`
+	// RetryPolicy is the delay between the retries and the errors not worth retrying, see RetryPolicy
+	RetryPolicy *RetryPolicyDefinition `json:"retry_policy,omitempty" yaml:"retry_policy,omitempty"`
+	// Timeout is a duration string like 30s
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Loop allows the edges leading back to the task, any other cycle is an error
+	Loop bool `json:"loop,omitempty" yaml:"loop,omitempty"`
+	// Compensator is the defined task rolling this one back
+	Compensator string `json:"compensator,omitempty" yaml:"compensator,omitempty"`
+
+	OnSuccess []string `json:"on_success,omitempty" yaml:"on_success,omitempty"`
+	OnFail    []string `json:"on_fail,omitempty" yaml:"on_fail,omitempty"`
+	OnAll     []string `json:"on_all,omitempty" yaml:"on_all,omitempty"`
+}
+
+// RetryPolicyDefinition declares the RetryPolicy, the intervals are duration strings like 30s
+type RetryPolicyDefinition struct {
+	InitialInterval time.Duration `json:"initial_interval,omitempty" yaml:"initial_interval,omitempty"`
+	MaxInterval     time.Duration `json:"max_interval,omitempty" yaml:"max_interval,omitempty"`
+	Multiplier      float64       `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+	Jitter          float64       `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+	// NonRetryable lists the error type names like InvalidRequest
+	NonRetryable []string `json:"non_retryable,omitempty" yaml:"non_retryable,omitempty"`
+}
+
+// JoinDefinition runs the defined task once Required of the parents finish with the condition, see Join
+type JoinDefinition struct {
+	TaskID    string        `json:"task_id" yaml:"task_id"`
+	Parents   []string      `json:"parents" yaml:"parents"`
+	Condition ConditionType `json:"condition" yaml:"condition"`
+	// Required is the number of parents to wait for, all parents by default
+	Required int `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+func (d *RetryPolicyDefinition) policy() (*RetryPolicy, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	var errs []error
+
+	nonRetryable := make([]ctxerrors.Type, 0, len(d.NonRetryable))
+	for _, name := range d.NonRetryable {
+		errorType, err := ctxerrors.ParseType(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		nonRetryable = append(nonRetryable, errorType)
+	}
+
+	if d.InitialInterval < 0 || d.MaxInterval < 0 {
+		errs = append(errs, errors.New("negative retry interval"))
+	}
+
+	if d.Multiplier < 0 {
+		errs = append(errs, errors.New("negative retry multiplier"))
+	}
+
+	if d.Jitter < 0 || d.Jitter > 1 {
+		errs = append(errs, errors.New("retry jitter is out of [0, 1]"))
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &RetryPolicy{
+		InitialInterval: d.InitialInterval,
+		MaxInterval:     d.MaxInterval,
+		Multiplier:      d.Multiplier,
+		Jitter:          d.Jitter,
+		NonRetryable:    nonRetryable,
+	}, nil
+}
+
+// ParseFlows decodes the YAML or JSON document and builds the validated flows.
+// Every task must be known by the creator, reachable from the flow head and not form a cycle
+// unless the cycle leads back to a task marked as loop.
+func ParseFlows(data []byte, creator TaskCreator) ([]*Flow, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var definition FlowsDefinition
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeInvalidRequest, "failed to decode flows definition")
+	}
+
+	return BuildFlows(definition, creator)
+}
+
+// ParseFlowsFile reads the flows definition from the file, see ParseFlows.
+func ParseFlowsFile(path string, creator TaskCreator) ([]*Flow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ctxerrors.Wrapf(err, ctxerrors.TypeInternal, "failed to read flows definition %s", path)
+	}
+
+	return ParseFlows(data, creator)
+}
+
+// BuildFlows validates the definition and builds the flows.
+// All validation errors are returned at once.
+func BuildFlows(definition FlowsDefinition, creator TaskCreator) ([]*Flow, error) {
+	var errs []error
+
+	flowIDs := make(map[string]struct{}, len(definition.Flows))
+	flows := make([]*Flow, 0, len(definition.Flows))
+
+	for _, flowDefinition := range definition.Flows {
+		if _, ok := flowIDs[flowDefinition.FlowID]; ok {
+			errs = append(errs, ctxerrors.Errorf(
+				ctxerrors.TypeInvalidRequest,
+				"flow %s: duplicated flow id",
+				flowDefinition.FlowID,
+			))
+
+			continue
+		}
+
+		flowIDs[flowDefinition.FlowID] = struct{}{}
+
+		if err := validateFlow(flowDefinition, creator); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		flows = append(flows, buildFlow(flowDefinition))
+	}
+
+	if len(errs) != 0 {
+		return nil, ctxerrors.Wrap(errors.Join(errs...), ctxerrors.TypeInvalidRequest, "invalid flows definition")
+	}
+
+	return flows, nil
+}
+
+func buildFlow(definition FlowDefinition) *Flow {
+	tasks := make(map[string]TaskDefinition, len(definition.Tasks))
+	for _, task := range definition.Tasks {
+		tasks[task.TaskID] = task
+	}
+
+	setting := func(taskID string) TaskSetting {
+		// the policy is validated with the flow
+		retryPolicy, _ := tasks[taskID].RetryPolicy.policy()
+
+		return TaskSetting{
+			TaskID:      taskID,
+			RunType:     tasks[taskID].RunType,
+			RetryCount:  tasks[taskID].RetryCount,
+			RetryPolicy: retryPolicy,
+			Timeout:     tasks[taskID].Timeout,
+		}
+	}
+
+	settings := func(taskIDs []string) []TaskSetting {
+		result := make([]TaskSetting, 0, len(taskIDs))
+		for _, taskID := range taskIDs {
+			taskSetting := setting(taskID)
+			if compensatorID := tasks[taskID].Compensator; compensatorID != "" {
+				compensator := setting(compensatorID)
+				taskSetting.Compensator = &compensator
+			}
+
+			result = append(result, taskSetting)
+		}
+
+		return result
+	}
+
+	flow := NewFlow(definition.FlowID)
+	for _, task := range definition.Tasks {
+		if len(task.OnSuccess) != 0 {
+			flow.AddCondition(task.TaskID, SuccessCondition, settings(task.OnSuccess)...)
+		}
+
+		if len(task.OnFail) != 0 {
+			flow.AddCondition(task.TaskID, FailCondition, settings(task.OnFail)...)
+		}
+
+		if len(task.OnAll) != 0 {
+			flow.AddCondition(task.TaskID, AllCondition, settings(task.OnAll)...)
+		}
+	}
+
+	for _, join := range definition.Joins {
+		flow.AddJoin(Join{
+			Parents:   join.Parents,
+			Condition: join.Condition,
+			Required:  join.Required,
+			Task:      settings([]string{join.TaskID})[0],
+		})
+	}
+
+	return flow
+}
+
+func validateFlow(definition FlowDefinition, creator TaskCreator) error {
+	invalid := func(format string, args ...interface{}) error {
+		return ctxerrors.Errorf(ctxerrors.TypeInvalidRequest, "flow %s: "+format, append([]interface{}{definition.FlowID}, args...)...)
+	}
+
+	if definition.FlowID == "" {
+		return ctxerrors.New(ctxerrors.TypeInvalidRequest, "flow id is empty")
+	}
+
+	var errs []error
+
+	tasks := make(map[string]TaskDefinition, len(definition.Tasks))
+	for _, task := range definition.Tasks {
+		if task.TaskID == "" {
+			errs = append(errs, invalid("task id is empty"))
+			continue
+		}
+
+		if _, ok := tasks[task.TaskID]; ok {
+			errs = append(errs, invalid("task %s: duplicated task id", task.TaskID))
+			continue
+		}
+
+		tasks[task.TaskID] = task
+
+		if task.RunType != SyncTask && task.RunType != AsyncTask {
+			errs = append(errs, invalid("task %s: unknown run type %q", task.TaskID, task.RunType))
+		}
+
+		if task.RetryCount < 0 {
+			errs = append(errs, invalid("task %s: negative retry count", task.TaskID))
+		}
+
+		if task.Timeout < 0 {
+			errs = append(errs, invalid("task %s: negative timeout", task.TaskID))
+		}
+
+		if _, err := task.RetryPolicy.policy(); err != nil {
+			errs = append(errs, ctxerrors.Wrapf(
+				err,
+				ctxerrors.TypeInvalidRequest,
+				"flow %s: task %s: invalid retry policy",
+				definition.FlowID,
+				task.TaskID,
+			))
+		}
+
+		if _, err := creator.Create(TaskMeta{TaskID: task.TaskID, FlowID: definition.FlowID, RunType: task.RunType}); err != nil {
+			errs = append(errs, ctxerrors.Wrapf(
+				err,
+				ctxerrors.TypeInvalidRequest,
+				"flow %s: task %s: unknown to the task creator",
+				definition.FlowID,
+				task.TaskID,
+			))
+		}
+	}
+
+	for _, task := range definition.Tasks {
+		for _, next := range successors(task) {
+			if _, ok := tasks[next]; !ok {
+				errs = append(errs, invalid("task %s: next task %s is not defined", task.TaskID, next))
+			}
+		}
+
+		if _, ok := tasks[task.Compensator]; task.Compensator != "" && !ok {
+			errs = append(errs, invalid("task %s: compensator %s is not defined", task.TaskID, task.Compensator))
+		}
+	}
+
+	errs = append(errs, validateJoins(definition.Joins, tasks, invalid)...)
+
+	if _, ok := tasks[definition.Head]; !ok {
+		errs = append(errs, invalid("head task %q is not defined", definition.Head))
+	}
+
+	// the graph checks below rely on the consistent tasks
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+
+	edges := flowEdges(definition)
+
+	reachable := map[string]bool{definition.Head: true}
+	queue := []string{definition.Head}
+
+	for len(queue) != 0 {
+		taskID := queue[0]
+		queue = queue[1:]
+
+		// the compensators are reached by the rollback of the flow
+		nextIDs := append([]string(nil), edges[taskID]...)
+		if compensatorID := tasks[taskID].Compensator; compensatorID != "" {
+			nextIDs = append(nextIDs, compensatorID)
+		}
+
+		for _, next := range nextIDs {
+			if !reachable[next] {
+				reachable[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for _, task := range definition.Tasks {
+		if !reachable[task.TaskID] {
+			errs = append(errs, invalid("task %s: unreachable from head %s", task.TaskID, definition.Head))
+		}
+	}
+
+	if cycle := findCycle(definition.Tasks, tasks, edges); len(cycle) != 0 {
+		errs = append(errs, invalid("unintended cycle %s", strings.Join(cycle, " -> ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// findCycle returns the first path closing a cycle, the edges to the loop tasks are skipped.
+func findCycle(order []TaskDefinition, tasks map[string]TaskDefinition, edges map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(tasks))
+	var path []string
+
+	var visit func(taskID string) []string
+	visit = func(taskID string) []string {
+		state[taskID] = visiting
+		path = append(path, taskID)
+
+		for _, next := range edges[taskID] {
+			if tasks[next].Loop {
+				continue
+			}
+
+			switch state[next] {
+			case visiting:
+				for i, id := range path {
+					if id == next {
+						return append(append([]string(nil), path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); len(cycle) != 0 {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[taskID] = visited
+
+		return nil
+	}
+
+	for _, task := range order {
+		if state[task.TaskID] != unvisited {
+			continue
+		}
+
+		if cycle := visit(task.TaskID); len(cycle) != 0 {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+func validateJoins(
+	joins []JoinDefinition,
+	tasks map[string]TaskDefinition,
+	invalid func(format string, args ...interface{}) error,
+) []error {
+	var errs []error
+
+	joinIDs := make(map[string]struct{}, len(joins))
+	for _, join := range joins {
+		if _, ok := tasks[join.TaskID]; !ok {
+			errs = append(errs, invalid("join %s: task is not defined", join.TaskID))
+		}
+
+		// the join state is kept by the join task id
+		if _, ok := joinIDs[join.TaskID]; ok {
+			errs = append(errs, invalid("join %s: duplicated join", join.TaskID))
+		}
+
+		joinIDs[join.TaskID] = struct{}{}
+
+		if len(join.Parents) == 0 {
+			errs = append(errs, invalid("join %s: no parents", join.TaskID))
+		}
+
+		parents := make(map[string]struct{}, len(join.Parents))
+		for _, parentID := range join.Parents {
+			if _, ok := tasks[parentID]; !ok {
+				errs = append(errs, invalid("join %s: parent %s is not defined", join.TaskID, parentID))
+			}
+
+			if _, ok := parents[parentID]; ok {
+				errs = append(errs, invalid("join %s: duplicated parent %s", join.TaskID, parentID))
+			}
+
+			parents[parentID] = struct{}{}
+		}
+
+		switch join.Condition {
+		case SuccessCondition, FailCondition, AllCondition:
+		default:
+			errs = append(errs, invalid("join %s: unknown condition %q", join.TaskID, join.Condition))
+		}
+
+		if join.Required < 0 || join.Required > len(join.Parents) {
+			errs = append(errs, invalid("join %s: required %d is out of the parents", join.TaskID, join.Required))
+		}
+	}
+
+	return errs
+}
+
+// flowEdges returns the next tasks of every task including the joins of its parents.
+func flowEdges(definition FlowDefinition) map[string][]string {
+	edges := make(map[string][]string, len(definition.Tasks))
+	for _, task := range definition.Tasks {
+		edges[task.TaskID] = successors(task)
+	}
+
+	for _, join := range definition.Joins {
+		for _, parentID := range join.Parents {
+			edges[parentID] = append(edges[parentID], join.TaskID)
+		}
+	}
+
+	return edges
+}
+
+func successors(task TaskDefinition) []string {
+	result := make([]string, 0, len(task.OnSuccess)+len(task.OnFail)+len(task.OnAll))
+	result = append(result, task.OnSuccess...)
+	result = append(result, task.OnFail...)
+
+	return append(result, task.OnAll...)
+}
@@ -0,0 +1,560 @@
+package taskmanager
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+)
+
+const testFlowsYAML = `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: ["1", "2"]
+      - task_id: "1"
+        run_type: async
+      - task_id: "2"
+        run_type: sync
+        retry_count: 2
+        on_fail: ["3"]
+        on_all: ["4"]
+      - task_id: "3"
+        run_type: async
+      - task_id: "4"
+        run_type: async
+`
+
+func TestParseFlows(t *testing.T) {
+	flows, err := ParseFlows([]byte(testFlowsYAML), &TestCreator{})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	flow := flows[0]
+
+	assert.Equal(t, []TaskMeta{
+		{TaskID: "1", FlowID: "flow_1", RunType: AsyncTask},
+		{TaskID: "2", FlowID: "flow_1", RunType: SyncTask, RetryCount: 2},
+	}, flow.GetTasks("head", SuccessCondition))
+	assert.Nil(t, flow.GetTasks("head", FailCondition))
+
+	assert.Equal(t, []TaskMeta{
+		{TaskID: "3", FlowID: "flow_1", RunType: AsyncTask},
+		{TaskID: "4", FlowID: "flow_1", RunType: AsyncTask},
+	}, flow.GetTasks("2", FailCondition))
+	assert.Equal(t, []TaskMeta{
+		{TaskID: "4", FlowID: "flow_1", RunType: AsyncTask},
+	}, flow.GetTasks("2", SuccessCondition))
+}
+
+func TestParseFlows_JSON(t *testing.T) {
+	data := `{"flows": [{"flow_id": "flow_1", "head": "head", "tasks": [
+		{"task_id": "head", "run_type": "sync", "on_success": ["1"]},
+		{"task_id": "1", "run_type": "sync"}
+	]}]}`
+
+	flows, err := ParseFlows([]byte(data), &TestCreator{})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	assert.Equal(t, []TaskMeta{
+		{TaskID: "1", FlowID: "flow_1", RunType: SyncTask},
+	}, flows[0].GetTasks("head", SuccessCondition))
+}
+
+func TestParseFlows_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		errors []string
+	}{
+		{
+			name: "unknown field",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_succes: ["1"]
+`,
+			errors: []string{"field on_succes not found"},
+		},
+		{
+			name: "unknown task",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: [unknown]
+      - task_id: unknown
+        run_type: async
+`,
+			errors: []string{"flow flow_1: task unknown: unknown to the task creator"},
+		},
+		{
+			name: "invalid tasks",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: start
+    tasks:
+      - task_id: head
+        run_type: later
+        retry_count: -1
+        on_success: ["1", "9"]
+      - task_id: "1"
+        run_type: async
+      - task_id: "1"
+        run_type: async
+`,
+			errors: []string{
+				`flow flow_1: task head: unknown run type "later"`,
+				"flow flow_1: task head: negative retry count",
+				"flow flow_1: task 1: duplicated task id",
+				"flow flow_1: task head: next task 9 is not defined",
+				`flow flow_1: head task "start" is not defined`,
+			},
+		},
+		{
+			name: "unreachable task",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+      - task_id: "1"
+        run_type: async
+`,
+			errors: []string{"flow flow_1: task 1: unreachable from head head"},
+		},
+		{
+			name: "cycle",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: ["1"]
+      - task_id: "1"
+        run_type: async
+        on_success: ["2"]
+      - task_id: "2"
+        run_type: async
+        on_fail: ["1"]
+`,
+			errors: []string{"flow flow_1: unintended cycle 1 -> 2 -> 1"},
+		},
+		{
+			name: "invalid retry policy",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        retry_count: 2
+        retry_policy:
+          initial_interval: -1s
+          jitter: 2
+          non_retryable: [Unknown, Missing]
+`,
+			errors: []string{
+				"flow flow_1: task head: invalid retry policy",
+				"Missing is not a valid Type",
+				"negative retry interval",
+				"retry jitter is out of [0, 1]",
+			},
+		},
+		{
+			name: "invalid joins",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: ["1"]
+      - task_id: "1"
+        run_type: async
+      - task_id: "3"
+        run_type: async
+    joins:
+      - task_id: "3"
+        parents: ["1", "1", "9"]
+        condition: done
+        required: 4
+      - task_id: "3"
+        parents: ["head"]
+        condition: success
+      - task_id: missing
+        condition: success
+`,
+			errors: []string{
+				"flow flow_1: join 3: duplicated parent 1",
+				"flow flow_1: join 3: parent 9 is not defined",
+				`flow flow_1: join 3: unknown condition "done"`,
+				"flow flow_1: join 3: required 4 is out of the parents",
+				"flow flow_1: join 3: duplicated join",
+				"flow flow_1: join missing: task is not defined",
+				"flow flow_1: join missing: no parents",
+			},
+		},
+		{
+			name: "join cycle",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: ["1", "2"]
+      - task_id: "1"
+        run_type: async
+      - task_id: "2"
+        run_type: async
+      - task_id: "3"
+        run_type: async
+        on_fail: ["1"]
+    joins:
+      - task_id: "3"
+        parents: ["1", "2"]
+        condition: all
+`,
+			errors: []string{"flow flow_1: unintended cycle 1 -> 3 -> 1"},
+		},
+		{
+			name: "duplicated flow",
+			data: `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+`,
+			errors: []string{"flow flow_1: duplicated flow id"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			flows, err := ParseFlows([]byte(tt.data), &TestCreator{})
+			require.Error(t, err)
+			assert.Nil(t, flows)
+			assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(err))
+
+			for _, message := range tt.errors {
+				assert.Contains(t, err.Error(), message)
+			}
+		})
+	}
+}
+
+func TestParseFlows_Loop(t *testing.T) {
+	data := `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: ["1"]
+      - task_id: "1"
+        run_type: async
+        loop: true
+        on_success: ["2"]
+      - task_id: "2"
+        run_type: async
+        on_fail: ["1"]
+`
+
+	flows, err := ParseFlows([]byte(data), &TestCreator{})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	assert.Equal(t, []TaskMeta{
+		{TaskID: "1", FlowID: "flow_1", RunType: AsyncTask},
+	}, flows[0].GetTasks("2", FailCondition))
+}
+
+func TestFlowManager_Load(t *testing.T) {
+	manager := NewFlowManager()
+	manager.AddFlow(NewFlow("flow_old"))
+
+	require.NoError(t, manager.Load([]byte(testFlowsYAML), &TestCreator{}))
+
+	_, err := manager.GetFlow("flow_old")
+	assert.Error(t, err)
+
+	flow, err := manager.GetFlow("flow_1")
+	require.NoError(t, err)
+
+	// the invalid definition keeps the loaded flows
+	assert.Error(t, manager.Load([]byte("flows: [{flow_id: flow_2}]"), &TestCreator{}))
+
+	current, err := manager.GetFlow("flow_1")
+	require.NoError(t, err)
+	assert.Same(t, flow, current)
+}
+
+func TestFlowManager_ReloadWhileRunning(t *testing.T) {
+	manager := NewFlowManager()
+	require.NoError(t, manager.Load([]byte(testFlowsYAML), &joinTestCreator{}))
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	// the tasks run concurrently with the reload, the creator is race safe
+	creator := &joinTestCreator{}
+	pool := NewPool(lg, creator, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = pool.Run(ctx, 2)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, manager.Load([]byte(testFlowsYAML), creator))
+		}
+	}()
+
+	results, err := pool.RunSync(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+	assert.NotEmpty(t, results)
+
+	<-done
+}
+
+// ackRecorder records the acknowledged tasks of the channel transport
+type ackRecorder struct {
+	*ChannelTransport
+
+	mtx    sync.Mutex
+	acks   []TaskMeta
+	noAcks []TaskMeta
+}
+
+func (r *ackRecorder) Ack(ctx context.Context, meta TaskMeta) {
+	r.mtx.Lock()
+	r.acks = append(r.acks, meta)
+	r.mtx.Unlock()
+
+	r.ChannelTransport.Ack(ctx, meta)
+}
+
+func (r *ackRecorder) NoAck(ctx context.Context, meta TaskMeta) {
+	r.mtx.Lock()
+	r.noAcks = append(r.noAcks, meta)
+	r.mtx.Unlock()
+
+	r.ChannelTransport.NoAck(ctx, meta)
+}
+
+func (r *ackRecorder) getAcks() ([]TaskMeta, []TaskMeta) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]TaskMeta(nil), r.acks...), append([]TaskMeta(nil), r.noAcks...)
+}
+
+func TestFlowManager_ReloadWithoutQueuedFlow(t *testing.T) {
+	manager := NewFlowManager()
+	require.NoError(t, manager.Load([]byte(testFlowsYAML), &joinTestCreator{}))
+
+	transport := &ackRecorder{ChannelTransport: NewChannelTransport(10)}
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	creator := &joinTestCreator{}
+	pool := NewPool(lg, creator, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	stale := TaskMeta{FlowID: "flow_1", TaskID: "1", RunID: "stale", RunType: AsyncTask}
+	require.NoError(t, transport.Publish(ctx, stale))
+
+	// the reload removes the flow of the queued task
+	require.NoError(t, manager.Load([]byte(strings.ReplaceAll(testFlowsYAML, "flow_1", "flow_2")), creator))
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- pool.Run(ctx, 1)
+	}()
+
+	require.NoError(t, transport.Publish(ctx, TaskMeta{FlowID: "flow_2", TaskID: "3", RunType: AsyncTask}))
+
+	assert.Eventually(t, func() bool {
+		return len(creator.getRuns("3")) == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	// the stale task is dropped once instead of the redelivery, the pool keeps running
+	acks, noAcks := transport.getAcks()
+	assert.Contains(t, acks, stale)
+	assert.Empty(t, noAcks)
+	assert.Empty(t, creator.getRuns("1"))
+
+	select {
+	case err = <-runErr:
+		t.Fatalf("pool stopped: %v", err)
+	default:
+	}
+
+	transport.Close()
+	assert.ErrorIs(t, <-runErr, ErrChannelClosed)
+}
+
+func TestParseFlows_Compensator(t *testing.T) {
+	data := `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: sync
+        on_success: ["1"]
+      - task_id: "1"
+        run_type: sync
+        compensator: "3"
+      - task_id: "3"
+        run_type: async
+        retry_count: 2
+`
+
+	flows, err := ParseFlows([]byte(data), &TestCreator{})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	assert.Equal(t, []TaskMeta{{
+		TaskID:      "1",
+		FlowID:      "flow_1",
+		RunType:     SyncTask,
+		Compensator: &TaskSetting{TaskID: "3", RunType: AsyncTask, RetryCount: 2},
+	}}, flows[0].GetTasks("head", SuccessCondition))
+
+	_, err = ParseFlows([]byte(strings.Replace(data, `compensator: "3"`, `compensator: "9"`, 1)), &TestCreator{})
+	assert.ErrorContains(t, err, "flow flow_1: task 1: compensator 9 is not defined")
+}
+
+func TestParseFlows_RetryPolicy(t *testing.T) {
+	data := `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: sync
+        on_success: ["1"]
+      - task_id: "1"
+        run_type: async
+        retry_count: 3
+        retry_policy:
+          initial_interval: 1s
+          max_interval: 1m
+          multiplier: 3
+          jitter: 0.2
+          non_retryable: [InvalidRequest, NotFound]
+`
+
+	flows, err := ParseFlows([]byte(data), &TestCreator{})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	assert.Equal(t, []TaskMeta{{
+		TaskID:     "1",
+		FlowID:     "flow_1",
+		RunType:    AsyncTask,
+		RetryCount: 3,
+		RetryPolicy: &RetryPolicy{
+			InitialInterval: time.Second,
+			MaxInterval:     time.Minute,
+			Multiplier:      3,
+			Jitter:          0.2,
+			NonRetryable:    []ctxerrors.Type{ctxerrors.TypeInvalidRequest, ctxerrors.TypeNotFound},
+		},
+	}}, flows[0].GetTasks("head", SuccessCondition))
+}
+
+func TestParseFlows_Join(t *testing.T) {
+	data := `
+flows:
+  - flow_id: flow_1
+    head: head
+    tasks:
+      - task_id: head
+        run_type: async
+        on_success: ["1", "2"]
+      - task_id: "1"
+        run_type: async
+      - task_id: "2"
+        run_type: async
+      - task_id: "4"
+        run_type: sync
+        retry_count: 2
+        compensator: "3"
+      - task_id: "3"
+        run_type: async
+    joins:
+      - task_id: "4"
+        parents: ["1", "2"]
+        condition: all
+        required: 1
+`
+
+	flows, err := ParseFlows([]byte(data), &TestCreator{})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+
+	join := Join{
+		Parents:   []string{"1", "2"},
+		Condition: AllCondition,
+		Required:  1,
+		Task: TaskSetting{
+			TaskID:      "4",
+			RunType:     SyncTask,
+			RetryCount:  2,
+			Compensator: &TaskSetting{TaskID: "3", RunType: AsyncTask},
+		},
+	}
+
+	assert.Equal(t, []Join{join}, flows[0].GetJoins("1", SuccessCondition))
+	assert.Equal(t, []Join{join}, flows[0].GetJoins("2", FailCondition))
+	assert.Empty(t, flows[0].GetJoins("head", SuccessCondition))
+}
@@ -0,0 +1,124 @@
+package taskmanager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/metrics"
+)
+
+const (
+	namespace = "taskmanager"
+	subsystem = "pool"
+
+	//labels
+	metricsFlowID     = "flow_id"
+	metricsTaskID     = "task_id"
+	metricsTaskStatus = "task_status"
+)
+
+type poolMetrics struct {
+	tasksCount    *prometheus.CounterVec
+	tasksDuration *prometheus.HistogramVec
+	queueLag      *prometheus.GaugeVec
+}
+
+func newPoolMetrics(logger *logger.Logger, enable bool) poolMetrics {
+	m := poolMetrics{
+		tasksCount: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "tasks_count",
+				Help:      "Total number of task transitions",
+			},
+			[]string{metricsFlowID, metricsTaskID, metricsTaskStatus},
+		),
+		tasksDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "tasks_duration_seconds",
+				Help:      "Task run time histogram (seconds)",
+				Buckets:   metrics.DefBuckets,
+			},
+			[]string{metricsFlowID, metricsTaskID, metricsTaskStatus},
+		),
+		queueLag: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "queue_lag_seconds",
+				Help:      "Time the last received task spent in the transport after it became due (seconds)",
+			},
+			[]string{metricsFlowID, metricsTaskID},
+		),
+	}
+
+	if enable {
+		// the pools of one process share the collectors
+		m.tasksCount = register(logger, m.tasksCount)
+		m.tasksDuration = register(logger, m.tasksDuration)
+		m.queueLag = register(logger, m.queueLag)
+	}
+
+	return m
+}
+
+func (m poolMetrics) incTask(meta TaskMeta, status TransitionType) {
+	m.tasksCount.With(m.labels(meta, status)).Inc()
+}
+
+func (m poolMetrics) registerTaskDuration(meta TaskMeta, status TransitionType, duration time.Duration) {
+	m.tasksDuration.With(m.labels(meta, status)).Observe(duration.Seconds())
+}
+
+// setQueueLag counts the lag from the time the task became due, the delayed tasks wait in the transport on purpose
+func (m poolMetrics) setQueueLag(meta TaskMeta, now time.Time) {
+	if meta.PublishedAt == nil {
+		return
+	}
+
+	due := *meta.PublishedAt
+	if meta.NotBefore != nil && meta.NotBefore.After(due) {
+		due = *meta.NotBefore
+	}
+
+	lag := now.Sub(due)
+	if lag < 0 {
+		lag = 0
+	}
+
+	m.queueLag.With(prometheus.Labels{
+		metricsFlowID: meta.FlowID,
+		metricsTaskID: meta.TaskID,
+	}).Set(lag.Seconds())
+}
+
+func (m poolMetrics) labels(meta TaskMeta, status TransitionType) prometheus.Labels {
+	return prometheus.Labels{
+		metricsFlowID:     meta.FlowID,
+		metricsTaskID:     meta.TaskID,
+		metricsTaskStatus: string(status),
+	}
+}
+
+func register[T prometheus.Collector](logger *logger.Logger, collector T) T {
+	err := prometheus.Register(collector)
+	if err == nil {
+		return collector
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+
+	logger.WithError(err).Warn("failed to register the metric")
+
+	return collector
+}
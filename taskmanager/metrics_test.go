@@ -0,0 +1,167 @@
+package taskmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPool_Metrics(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition, TaskSetting{TaskID: "2", RunType: SyncTask, RetryCount: 2})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, &TestCreator{}, transport, transport, manager, WithMetrics())
+
+	// the second pool shares the registered collectors
+	second := NewPool(lg, &TestCreator{}, transport, transport, manager, WithMetrics())
+	require.Same(t, pool.metrics.tasksCount, second.metrics.tasksCount)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = pool.RunSync(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+
+	count := func(taskID string, status TransitionType) float64 {
+		return testutil.ToFloat64(pool.metrics.tasksCount.With(prometheus.Labels{
+			metricsFlowID:     "flow_1",
+			metricsTaskID:     taskID,
+			metricsTaskStatus: string(status),
+		}))
+	}
+
+	assert.Equal(t, float64(1), count("head", TransitionStarted))
+	assert.Equal(t, float64(1), count("head", TransitionSucceeded))
+	assert.Equal(t, float64(2), count("2", TransitionStarted))
+	assert.Equal(t, float64(1), count("2", TransitionRetried))
+	assert.Equal(t, float64(1), count("2", TransitionFailed))
+
+	assert.Equal(t, 3, testutil.CollectAndCount(pool.metrics.tasksDuration))
+
+	publishedAt := time.Now().Add(-time.Second * 3)
+	notBefore := publishedAt.Add(time.Second)
+	pool.metrics.setQueueLag(TaskMeta{FlowID: "flow_1", TaskID: "2", PublishedAt: &publishedAt, NotBefore: &notBefore}, publishedAt.Add(time.Second*3))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(pool.metrics.queueLag.With(prometheus.Labels{
+		metricsFlowID: "flow_1",
+		metricsTaskID: "2",
+	})))
+}
+
+func TestPool_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition, TaskSetting{TaskID: "1", RunType: AsyncTask})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, &TestCreator{}, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = pool.Run(ctx, 1)
+	}()
+
+	_, err = pool.RunSync(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(recorder.Ended()) == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	spans := recorder.Ended()
+	head, next := spans[0], spans[1]
+
+	assert.Equal(t, "head", head.Name())
+	assert.Equal(t, "1", next.Name())
+	assert.Equal(t, trace.SpanKindConsumer, next.SpanKind())
+
+	// the async task starts its own trace linked to the producer
+	assert.NotEqual(t, head.SpanContext().TraceID(), next.SpanContext().TraceID())
+	assert.False(t, next.Parent().IsValid())
+	require.Len(t, next.Links(), 1)
+	assert.Equal(t, head.SpanContext().SpanID(), next.Links()[0].SpanContext.SpanID())
+	assert.Equal(t, head.SpanContext().TraceID(), next.Links()[0].SpanContext.TraceID())
+}
+
+func TestPool_SyncSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	// the tracing provider samples all the spans, the restored producer carries no sampled flag
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	flow := NewFlow("flow_1")
+	flow.AddCondition("head", SuccessCondition, TaskSetting{TaskID: "1", RunType: SyncTask})
+	flow.AddCondition("1", SuccessCondition, TaskSetting{TaskID: "3", RunType: SyncTask})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, &TestCreator{}, transport, transport, manager)
+
+	_, err = pool.RunSync(context.Background(), TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	// the sync task continues the trace as the child of the producer
+	for i, name := range []string{"head", "1", "3"} {
+		assert.Equal(t, name, spans[i].Name())
+		assert.Equal(t, trace.SpanKindInternal, spans[i].SpanKind())
+		assert.Empty(t, spans[i].Links())
+
+		if i == 0 {
+			continue
+		}
+
+		assert.Equal(t, spans[0].SpanContext().TraceID(), spans[i].SpanContext().TraceID())
+		assert.Equal(t, spans[i-1].SpanContext().SpanID(), spans[i].Parent().SpanID())
+	}
+}
@@ -0,0 +1,324 @@
+package pgstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+const (
+	joinsTable        = "taskmanager_joins"
+	joinArrivalsTable = "taskmanager_join_arrivals"
+
+	rollbackTimeout = 5 * time.Second
+)
+
+var _ taskmanager.JoinStore = (*JoinStore)(nil)
+
+// JoinStore keeps the joins state in postgres, so the branches of one flow run may finish on different replicas.
+// The state expired after its last arrival is deleted by the next arrivals.
+type JoinStore struct {
+	logger  *logger.Logger
+	storage goKitPgx.Storage
+	ttl     time.Duration
+}
+
+type JoinStoreOption func(*JoinStore)
+
+// WithJoinTTL Option to determine how long the join state is kept after its last arrival,
+// taskmanager.DefaultJoinTTL by default
+func WithJoinTTL(ttl time.Duration) JoinStoreOption {
+	return func(s *JoinStore) {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+	}
+}
+
+func NewJoinStore(logger *logger.Logger, storage goKitPgx.Storage, opts ...JoinStoreOption) *JoinStore {
+	s := &JoinStore{
+		logger:  logger.Named("task_manager_join_store"),
+		storage: storage,
+		ttl:     taskmanager.DefaultJoinTTL,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *JoinStore) Arrive(
+	ctx context.Context,
+	arrival taskmanager.JoinArrival,
+) (map[string]taskmanager.JoinResult, bool, error) {
+	result, err := json.Marshal(arrival.Result)
+	if err != nil {
+		return nil, false, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to marshal join result")
+	}
+
+	compensations, err := json.Marshal(arrival.Compensations)
+	if err != nil {
+		return nil, false, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to marshal join compensations")
+	}
+
+	tx, err := s.storage.Begin(ctx, nil)
+	if err != nil {
+		return nil, false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to begin transaction")
+	}
+
+	defer func() {
+		rCtx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+
+		if err = tx.Rollback(rCtx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.
+				WithCtx(rCtx).
+				WithError(err).
+				Error("rollback failed")
+		}
+
+		cancel()
+	}()
+
+	if err = deleteExpiredJoins(ctx, tx, s.ttl); err != nil {
+		return nil, false, err
+	}
+
+	// the join row serializes concurrent arrivals of one run
+	fired, completed, err := lockJoin(ctx, tx, arrival)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the redelivered parent of the completed join
+	if completed {
+		if err = tx.Commit(ctx); err != nil {
+			return nil, false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to commit transaction")
+		}
+
+		return nil, false, nil
+	}
+
+	if err = insertArrival(ctx, tx, arrival, result, compensations); err != nil {
+		return nil, false, err
+	}
+
+	results, err := getArrivals(ctx, tx, arrival)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ready := !fired && len(results) >= arrival.Required
+
+	switch {
+	case len(results) >= arrival.Total:
+		// nothing else can arrive, the join row is kept as the completed marker only
+		err = completeJoin(ctx, tx, arrival)
+	case ready:
+		err = fireJoin(ctx, tx, arrival)
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to commit transaction")
+	}
+
+	return results, ready, nil
+}
+
+func lockJoin(ctx context.Context, tx goKitPgx.Transaction, arrival taskmanager.JoinArrival) (bool, bool, error) {
+	sql, args, err := sq.Insert(joinsTable).
+		Columns("run_id", "join_id").
+		Values(arrival.RunID, arrival.JoinID).
+		Suffix("ON CONFLICT (run_id, join_id) DO UPDATE SET updated_at = now() RETURNING fired, completed").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	var fired, completed bool
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&fired, &completed); err != nil {
+		return false, false, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to lock join")
+	}
+
+	return fired, completed, nil
+}
+
+func insertArrival(
+	ctx context.Context,
+	tx goKitPgx.Transaction,
+	arrival taskmanager.JoinArrival,
+	result, compensations []byte,
+) error {
+	sql, args, err := sq.Insert(joinArrivalsTable).
+		Columns("run_id", "join_id", "parent_id", "result", "compensations").
+		Values(arrival.RunID, arrival.JoinID, arrival.ParentID, result, compensations).
+		Suffix("ON CONFLICT (run_id, join_id, parent_id) DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to insert join arrival")
+	}
+
+	return nil
+}
+
+func getArrivals(
+	ctx context.Context,
+	tx goKitPgx.Transaction,
+	arrival taskmanager.JoinArrival,
+) (map[string]taskmanager.JoinResult, error) {
+	sql, args, err := sq.Select("parent_id", "result", "compensations").
+		From(joinArrivalsTable).
+		Where(sq.Eq{"run_id": arrival.RunID}).
+		Where(sq.Eq{"join_id": arrival.JoinID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get join arrivals")
+	}
+
+	defer rows.Close()
+
+	results := make(map[string]taskmanager.JoinResult)
+	for rows.Next() {
+		var (
+			parentID      string
+			data          []byte
+			compensations []byte
+			result        taskmanager.JoinResult
+		)
+
+		if err = rows.Scan(&parentID, &data, &compensations); err != nil {
+			return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to scan join arrival")
+		}
+
+		if len(data) != 0 {
+			if err = json.Unmarshal(data, &result.Result); err != nil {
+				return nil, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to unmarshal join result")
+			}
+		}
+
+		if len(compensations) != 0 {
+			if err = json.Unmarshal(compensations, &result.Compensations); err != nil {
+				return nil, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to unmarshal join compensations")
+			}
+		}
+
+		results[parentID] = result
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to read join arrivals")
+	}
+
+	return results, nil
+}
+
+func fireJoin(ctx context.Context, tx goKitPgx.Transaction, arrival taskmanager.JoinArrival) error {
+	sql, args, err := sq.Update(joinsTable).
+		Set("fired", true).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"run_id": arrival.RunID}).
+		Where(sq.Eq{"join_id": arrival.JoinID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to fire join")
+	}
+
+	return nil
+}
+
+func completeJoin(ctx context.Context, tx goKitPgx.Transaction, arrival taskmanager.JoinArrival) error {
+	sql, args, err := sq.Delete(joinArrivalsTable).
+		Where(sq.Eq{"run_id": arrival.RunID}).
+		Where(sq.Eq{"join_id": arrival.JoinID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to delete join arrivals")
+	}
+
+	sql, args, err = sq.Update(joinsTable).
+		Set("fired", true).
+		Set("completed", true).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"run_id": arrival.RunID}).
+		Where(sq.Eq{"join_id": arrival.JoinID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to complete join")
+	}
+
+	return nil
+}
+
+// deleteExpiredJoins deletes the joins with their arrivals not updated within the ttl
+func deleteExpiredJoins(ctx context.Context, tx goKitPgx.Transaction, ttl time.Duration) error {
+	expired := sq.Expr("updated_at < now() - make_interval(secs => ?)", ttl.Seconds())
+
+	arrivals, args, err := sq.Delete(joinArrivalsTable).
+		Where(sq.Expr(
+			"(run_id, join_id) IN (?)",
+			sq.Select("run_id", "join_id").From(joinsTable).Where(expired),
+		)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, arrivals, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to delete expired join arrivals")
+	}
+
+	joins, args, err := sq.Delete(joinsTable).
+		Where(expired).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, joins, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to delete expired joins")
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package pgstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+func (s *TestSuite) Test_JoinStore_Arrive() {
+	ctx := context.Background()
+	store := NewJoinStore(s.logger, s.db)
+
+	arrival := taskmanager.JoinArrival{RunID: "run", JoinID: "join", Required: 2, Total: 3}
+
+	compensations := []taskmanager.Compensation{{
+		StepID:      "step",
+		Compensator: taskmanager.TaskSetting{TaskID: "compensator", RunType: taskmanager.AsyncTask},
+		Result:      "step_result",
+	}}
+
+	arrival.ParentID, arrival.Result = "1", map[string]interface{}{"value": "result_1"}
+	arrival.Compensations = compensations
+	results, ready, err := store.Arrive(ctx, arrival)
+	s.Require().NoError(err)
+	s.False(ready)
+	s.Equal(map[string]taskmanager.JoinResult{
+		"1": {Result: map[string]interface{}{"value": "result_1"}, Compensations: compensations},
+	}, results)
+
+	// repeated arrival is ignored
+	_, ready, err = store.Arrive(ctx, arrival)
+	s.Require().NoError(err)
+	s.False(ready)
+
+	arrival.ParentID, arrival.Result, arrival.Compensations = "2", nil, nil
+	results, ready, err = store.Arrive(ctx, arrival)
+	s.Require().NoError(err)
+	s.True(ready)
+	s.Len(results, 2)
+	s.Equal(compensations, results["1"].Compensations)
+	s.Equal(taskmanager.JoinResult{}, results["2"])
+
+	// the join is ready once and the state is released after the last parent
+	arrival.ParentID, arrival.Result = "3", "result_3"
+	_, ready, err = store.Arrive(ctx, arrival)
+	s.Require().NoError(err)
+	s.False(ready)
+
+	var count int
+	s.Require().NoError(s.db.QueryRow(ctx, "SELECT COUNT(1) FROM "+joinArrivalsTable).Scan(&count))
+	s.Zero(count)
+}
+
+func (s *TestSuite) Test_JoinStore_Redelivery() {
+	ctx := context.Background()
+	store := NewJoinStore(s.logger, s.db, WithJoinTTL(time.Hour))
+
+	arrival := taskmanager.JoinArrival{RunID: "run", JoinID: "join", Required: 1, Total: 2}
+
+	arrival.ParentID = "1"
+	_, ready, err := store.Arrive(ctx, arrival)
+	s.Require().NoError(err)
+	s.True(ready)
+
+	arrival.ParentID = "2"
+	_, ready, err = store.Arrive(ctx, arrival)
+	s.Require().NoError(err)
+	s.False(ready)
+
+	// the redelivered parents of the completed join do not fire it again
+	for _, parentID := range []string{"1", "2"} {
+		arrival.ParentID = parentID
+		results, ready, err := store.Arrive(ctx, arrival)
+		s.Require().NoError(err)
+		s.False(ready)
+		s.Empty(results)
+	}
+
+	var count int
+	s.Require().NoError(s.db.QueryRow(ctx, "SELECT COUNT(1) FROM "+joinArrivalsTable).Scan(&count))
+	s.Zero(count)
+
+	// the expired state is deleted by the next arrival
+	_, err = s.db.Exec(ctx, "UPDATE "+joinsTable+" SET updated_at = now() - interval '2 hours'")
+	s.Require().NoError(err)
+
+	_, _, err = store.Arrive(ctx, taskmanager.JoinArrival{RunID: "other", JoinID: "join", ParentID: "1", Required: 2, Total: 2})
+	s.Require().NoError(err)
+
+	var runID string
+	s.Require().NoError(s.db.QueryRow(ctx, "SELECT string_agg(run_id, ',') FROM "+joinsTable).Scan(&runID))
+	s.Equal("other", runID)
+}
+
+func (s *TestSuite) Test_JoinStore_ArriveConcurrently() {
+	ctx := context.Background()
+	store := NewJoinStore(s.logger, s.db)
+
+	const parents = 10
+
+	var (
+		wg    sync.WaitGroup
+		mtx   sync.Mutex
+		fired int
+	)
+
+	for i := 0; i < parents; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, ready, err := store.Arrive(ctx, taskmanager.JoinArrival{
+				RunID:    "run",
+				JoinID:   "join",
+				ParentID: fmt.Sprint(i),
+				Required: parents / 2,
+				Total:    parents,
+			})
+			s.NoError(err)
+
+			if ready {
+				mtx.Lock()
+				fired++
+				mtx.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	s.Equal(1, fired)
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS taskmanager_joins
+(
+    run_id     varchar                 not null,
+    join_id    varchar                 not null,
+    fired      boolean   default false not null,
+    created_at timestamp default now() not null,
+    updated_at timestamp default now() not null,
+    primary key (run_id, join_id)
+);
+
+CREATE TABLE IF NOT EXISTS taskmanager_join_arrivals
+(
+    run_id     varchar                 not null,
+    join_id    varchar                 not null,
+    parent_id  varchar                 not null,
+    result     jsonb,
+    created_at timestamp default now() not null,
+    primary key (run_id, join_id, parent_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS taskmanager_join_arrivals;
+DROP TABLE IF EXISTS taskmanager_joins;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS taskmanager_runs
+(
+    run_id       varchar   not null primary key,
+    flow_id      varchar   not null,
+    current_task varchar   not null,
+    status       varchar   not null,
+    started_at   timestamp not null,
+    updated_at   timestamp not null
+);
+
+CREATE INDEX IF NOT EXISTS taskmanager_runs_flow_id_started_at_idx ON taskmanager_runs (flow_id, started_at);
+CREATE INDEX IF NOT EXISTS taskmanager_runs_started_at_idx ON taskmanager_runs (started_at);
+
+CREATE TABLE IF NOT EXISTS taskmanager_run_transitions
+(
+    id         bigserial primary key,
+    run_id     varchar              not null,
+    flow_id    varchar              not null,
+    task_id    varchar              not null,
+    type       varchar              not null,
+    fail_count integer   default 0  not null,
+    error      varchar   default '' not null,
+    created_at timestamp            not null
+);
+
+CREATE INDEX IF NOT EXISTS taskmanager_run_transitions_run_id_idx ON taskmanager_run_transitions (run_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS taskmanager_run_transitions;
+DROP TABLE IF EXISTS taskmanager_runs;
+-- +goose StatementEnd
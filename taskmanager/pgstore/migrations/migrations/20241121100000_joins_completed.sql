@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE taskmanager_joins
+    ADD COLUMN IF NOT EXISTS completed boolean default false not null;
+
+CREATE INDEX IF NOT EXISTS taskmanager_joins_updated_at_idx ON taskmanager_joins (updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS taskmanager_joins_updated_at_idx;
+
+ALTER TABLE taskmanager_joins
+    DROP COLUMN IF EXISTS completed;
+-- +goose StatementEnd
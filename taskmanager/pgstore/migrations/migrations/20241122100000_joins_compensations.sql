@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE taskmanager_join_arrivals
+    ADD COLUMN IF NOT EXISTS compensations jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE taskmanager_join_arrivals
+    DROP COLUMN IF EXISTS compensations;
+-- +goose StatementEnd
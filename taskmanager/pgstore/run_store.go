@@ -0,0 +1,219 @@
+package pgstore
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+const (
+	runsTable           = "taskmanager_runs"
+	runTransitionsTable = "taskmanager_run_transitions"
+)
+
+var _ taskmanager.RunStore = (*RunStore)(nil)
+
+// RunStore keeps the flow runs and their transitions in postgres.
+type RunStore struct {
+	logger  *logger.Logger
+	storage goKitPgx.Storage
+}
+
+func NewRunStore(logger *logger.Logger, storage goKitPgx.Storage) *RunStore {
+	return &RunStore{
+		logger:  logger.Named("task_manager_run_store"),
+		storage: storage,
+	}
+}
+
+func (s *RunStore) AddTransition(ctx context.Context, transition taskmanager.Transition) error {
+	tx, err := s.storage.Begin(ctx, nil)
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to begin transaction")
+	}
+
+	defer func() {
+		rCtx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+
+		if err = tx.Rollback(rCtx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.
+				WithCtx(rCtx).
+				WithError(err).
+				Error("rollback failed")
+		}
+
+		cancel()
+	}()
+
+	if err = insertTransition(ctx, tx, transition); err != nil {
+		return err
+	}
+
+	if err = upsertRun(ctx, tx, transition); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (s *RunStore) GetRuns(ctx context.Context, filter taskmanager.RunFilter) ([]taskmanager.Run, error) {
+	query := sq.Select(runColumns()...).
+		From(runsTable).
+		OrderBy("started_at DESC", "run_id").
+		PlaceholderFormat(sq.Dollar)
+
+	if filter.FlowID != "" {
+		query = query.Where(sq.Eq{"flow_id": filter.FlowID})
+	}
+
+	if filter.Status != "" {
+		query = query.Where(sq.Eq{"status": filter.Status})
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+
+	if filter.Offset > 0 {
+		query = query.Offset(uint64(filter.Offset))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := s.storage.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get runs")
+	}
+
+	runs, err := pgx.CollectRows[taskmanager.Run](rows, pgx.RowToStructByName[taskmanager.Run])
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect runs")
+	}
+
+	return runs, nil
+}
+
+func (s *RunStore) GetRun(ctx context.Context, runID string) (taskmanager.Run, error) {
+	sql, args, err := sq.Select(runColumns()...).
+		From(runsTable).
+		Where(sq.Eq{"run_id": runID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return taskmanager.Run{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := s.storage.Query(ctx, sql, args...)
+	if err != nil {
+		return taskmanager.Run{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get run")
+	}
+
+	run, err := pgx.CollectOneRow[taskmanager.Run](rows, pgx.RowToStructByName[taskmanager.Run])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return taskmanager.Run{}, ctxerrors.Wrapf(err, ctxerrors.TypeNotFound, "run with id %s is not exists", runID)
+		}
+
+		return taskmanager.Run{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect run")
+	}
+
+	return run, nil
+}
+
+func (s *RunStore) GetHistory(ctx context.Context, runID string) ([]taskmanager.Transition, error) {
+	sql, args, err := sq.Select(transitionColumns()...).
+		From(runTransitionsTable).
+		Where(sq.Eq{"run_id": runID}).
+		OrderBy("id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	rows, err := s.storage.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get run history")
+	}
+
+	history, err := pgx.CollectRows[taskmanager.Transition](rows, pgx.RowToStructByName[taskmanager.Transition])
+	if err != nil {
+		return nil, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to collect run history")
+	}
+
+	if len(history) == 0 {
+		return nil, ctxerrors.Errorf(ctxerrors.TypeNotFound, "run with id %s is not exists", runID)
+	}
+
+	return history, nil
+}
+
+func insertTransition(ctx context.Context, tx goKitPgx.Transaction, transition taskmanager.Transition) error {
+	sql, args, err := sq.Insert(runTransitionsTable).
+		Columns(transitionColumns()...).
+		Values(
+			transition.RunID,
+			transition.FlowID,
+			transition.TaskID,
+			transition.Type,
+			transition.FailCount,
+			transition.Error,
+			transition.CreatedAt.UTC(),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to insert run transition")
+	}
+
+	return nil
+}
+
+func upsertRun(ctx context.Context, tx goKitPgx.Transaction, transition taskmanager.Transition) error {
+	createdAt := transition.CreatedAt.UTC()
+
+	sql, args, err := sq.Insert(runsTable).
+		Columns(runColumns()...).
+		Values(transition.RunID, transition.FlowID, transition.TaskID, transition.Type, createdAt, createdAt).
+		Suffix(`ON CONFLICT (run_id) DO UPDATE SET
+			current_task = EXCLUDED.current_task,
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at
+		WHERE `+runsTable+`.status <> ?`, string(taskmanager.TransitionCanceled)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to upsert run")
+	}
+
+	return nil
+}
+
+func runColumns() []string {
+	return []string{"run_id", "flow_id", "current_task", "status", "started_at", "updated_at"}
+}
+
+func transitionColumns() []string {
+	return []string{"run_id", "flow_id", "task_id", "type", "fail_count", "error", "created_at"}
+}
@@ -0,0 +1,94 @@
+package pgstore
+
+import (
+	"context"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+func (s *TestSuite) Test_RunStore() {
+	ctx := context.Background()
+	store := NewRunStore(s.logger, s.db)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	transitions := []taskmanager.Transition{
+		{RunID: "run_1", FlowID: "flow_1", TaskID: "head", Type: taskmanager.TransitionStarted, CreatedAt: now},
+		{RunID: "run_2", FlowID: "flow_2", TaskID: "head", Type: taskmanager.TransitionStarted, CreatedAt: now.Add(time.Second)},
+		{
+			RunID:     "run_1",
+			FlowID:    "flow_1",
+			TaskID:    "head",
+			Type:      taskmanager.TransitionRetried,
+			FailCount: 1,
+			Error:     "test error",
+			CreatedAt: now.Add(time.Second),
+		},
+		{RunID: "run_3", FlowID: "flow_1", TaskID: "head", Type: taskmanager.TransitionStarted, CreatedAt: now.Add(time.Second * 2)},
+	}
+
+	for _, transition := range transitions {
+		s.Require().NoError(store.AddTransition(ctx, transition))
+	}
+
+	run, err := store.GetRun(ctx, "run_1")
+	s.Require().NoError(err)
+	s.Equal(taskmanager.Run{
+		RunID:       "run_1",
+		FlowID:      "flow_1",
+		CurrentTask: "head",
+		Status:      taskmanager.TransitionRetried,
+		StartedAt:   now,
+		UpdatedAt:   now.Add(time.Second),
+	}, run)
+
+	history, err := store.GetHistory(ctx, "run_1")
+	s.Require().NoError(err)
+	s.Equal([]taskmanager.Transition{transitions[0], transitions[2]}, history)
+
+	runs, err := store.GetRuns(ctx, taskmanager.RunFilter{})
+	s.Require().NoError(err)
+	s.Require().Len(runs, 3)
+	s.Equal("run_3", runs[0].RunID)
+
+	runs, err = store.GetRuns(ctx, taskmanager.RunFilter{FlowID: "flow_1", Limit: 1, Offset: 1})
+	s.Require().NoError(err)
+	s.Require().Len(runs, 1)
+	s.Equal("run_1", runs[0].RunID)
+
+	runs, err = store.GetRuns(ctx, taskmanager.RunFilter{Status: taskmanager.TransitionStarted})
+	s.Require().NoError(err)
+	s.Len(runs, 2)
+
+	_, err = store.GetRun(ctx, "unknown")
+	s.Equal(ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+
+	_, err = store.GetHistory(ctx, "unknown")
+	s.Equal(ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+
+	// the canceled run keeps its status
+	s.Require().NoError(store.AddTransition(ctx, taskmanager.Transition{
+		RunID:     "run_3",
+		FlowID:    "flow_1",
+		TaskID:    "head",
+		Type:      taskmanager.TransitionCanceled,
+		CreatedAt: now.Add(time.Second * 3),
+	}))
+	s.Require().NoError(store.AddTransition(ctx, taskmanager.Transition{
+		RunID:     "run_3",
+		FlowID:    "flow_1",
+		TaskID:    "next",
+		Type:      taskmanager.TransitionSucceeded,
+		CreatedAt: now.Add(time.Second * 4),
+	}))
+
+	run, err = store.GetRun(ctx, "run_3")
+	s.Require().NoError(err)
+	s.Equal(taskmanager.TransitionCanceled, run.Status)
+	s.Equal("head", run.CurrentTask)
+
+	history, err = store.GetHistory(ctx, "run_3")
+	s.Require().NoError(err)
+	s.Len(history, 3)
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS taskmanager_queue
+(
+    id         bigserial primary key,
+    queue      varchar                 not null,
+    meta       jsonb                   not null,
+    attempts   int       default 0     not null,
+    visible_at timestamp default now() not null,
+    created_at timestamp default now() not null,
+    updated_at timestamp default now() not null
+);
+
+CREATE INDEX IF NOT EXISTS idx_taskmanager_queue_visible ON taskmanager_queue (queue, visible_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_taskmanager_queue_visible;
+
+DROP TABLE IF EXISTS taskmanager_queue;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+package pgtransport
+
+import "time"
+
+const defaultTable = "taskmanager_queue"
+
+type options struct {
+	Table             string
+	PollInterval      time.Duration
+	VisibilityTimeout time.Duration
+}
+
+func defaultOptions() options {
+	return options{
+		Table:             defaultTable,
+		PollInterval:      time.Second,
+		VisibilityTimeout: time.Minute * 5,
+	}
+}
+
+type Option func(*options)
+
+// WithTable Defines the name of the table for storing queued tasks, instead of the default
+func WithTable(table string) Option {
+	return func(o *options) {
+		o.Table = table
+	}
+}
+
+// WithPollInterval Defines how often Subscribe looks for new tasks when the queue is empty
+func WithPollInterval(dur time.Duration) Option {
+	return func(o *options) {
+		o.PollInterval = dur
+	}
+}
+
+// WithVisibilityTimeout Defines how long a received task stays hidden from other subscribers.
+// A task that is neither acked nor nacked within this time is redelivered.
+func WithVisibilityTimeout(dur time.Duration) Option {
+	return func(o *options) {
+		o.VisibilityTimeout = dur
+	}
+}
@@ -0,0 +1,143 @@
+package pgtransport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+// errDeliveryLost means the task is claimed again after the visibility timeout,
+// the ack or no ack of the previous delivery is dropped
+var errDeliveryLost = errors.New("task delivery lost")
+
+// delivery is the receipt of the claimed task, the attempts fence the ack of the previous deliveries
+type delivery struct {
+	id       uint64
+	attempts int
+}
+
+func (t *Transport) insertTasks(ctx context.Context, metas []taskmanager.TaskMeta) error {
+	query := sq.Insert(t.config.Table).
+		Columns("queue", "meta", "visible_at")
+
+	for _, meta := range metas {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to marshal task meta")
+		}
+
+		var visibleAt interface{} = sq.Expr("now()")
+		if meta.NotBefore != nil {
+			visibleAt = meta.NotBefore.UTC()
+		}
+
+		query = query.Values(t.queue, data, visibleAt)
+	}
+
+	sql, args, err := query.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	if _, err = t.storage.Exec(ctx, sql, args...); err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to make query")
+	}
+
+	return nil
+}
+
+// claimTask hides the oldest visible task for the visibility timeout and returns it.
+// Concurrent subscribers skip rows locked by each other, so every task is claimed once.
+func (t *Transport) claimTask(ctx context.Context) (delivery, taskmanager.TaskMeta, error) {
+	visible := sq.Select("id").
+		From(t.config.Table).
+		Where(sq.Eq{"queue": t.queue}).
+		Where(sq.Expr("visible_at <= now()")).
+		OrderBy("id ASC").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, err := sq.Update(t.config.Table).
+		Set("visible_at", sq.Expr("now() + make_interval(secs => ?)", t.config.VisibilityTimeout.Seconds())).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Expr("id = (?)", visible)).
+		Suffix("RETURNING id, attempts, meta").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return delivery{}, taskmanager.TaskMeta{}, ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	var (
+		d    delivery
+		data []byte
+	)
+
+	if err = t.storage.QueryRow(ctx, sql, args...).Scan(&d.id, &d.attempts, &data); err != nil {
+		return delivery{}, taskmanager.TaskMeta{}, err
+	}
+
+	var meta taskmanager.TaskMeta
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return d, taskmanager.TaskMeta{}, ctxerrors.Wrap(err, ctxerrors.TypeInternal, "failed to unmarshal task meta")
+	}
+
+	return d, meta, nil
+}
+
+// deleteTask deletes the task unless it is claimed again after the delivery
+func (t *Transport) deleteTask(ctx context.Context, d delivery) error {
+	sql, args, err := sq.Delete(t.config.Table).
+		Where(sq.Eq{
+			"id":       d.id,
+			"attempts": d.attempts,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := t.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to make query")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errDeliveryLost
+	}
+
+	return nil
+}
+
+// releaseTask makes the task visible unless it is claimed again after the delivery
+func (t *Transport) releaseTask(ctx context.Context, d delivery) error {
+	sql, args, err := sq.Update(t.config.Table).
+		Set("visible_at", sq.Expr("now()")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"id":       d.id,
+			"attempts": d.attempts,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to get query string")
+	}
+
+	tag, err := t.storage.Exec(ctx, sql, args...)
+	if err != nil {
+		return ctxerrors.Wrap(err, ctxerrors.TypeDatabase, "failed to make query")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errDeliveryLost
+	}
+
+	return nil
+}
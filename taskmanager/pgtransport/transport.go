@@ -0,0 +1,138 @@
+package pgtransport
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/underbek/examples-go/logger"
+	goKitPgx "github.com/underbek/examples-go/storage/pgx"
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+var _ taskmanager.Publisher = (*Transport)(nil)
+var _ taskmanager.Subscriber = (*Transport)(nil)
+
+// Transport is a durable taskmanager.Publisher and taskmanager.Subscriber stored in postgres.
+// Several replicas may subscribe to the same queue, each task is delivered to one of them.
+type Transport struct {
+	logger  *logger.Logger
+	storage goKitPgx.Storage
+	queue   string
+	config  options
+}
+
+func New(logger *logger.Logger, storage goKitPgx.Storage, queue string, opts ...Option) *Transport {
+	t := &Transport{
+		logger:  logger.Named("task_manager_pg_transport"),
+		storage: storage,
+		queue:   queue,
+		config:  defaultOptions(),
+	}
+
+	for _, opt := range opts {
+		opt(&t.config)
+	}
+
+	return t
+}
+
+func (t *Transport) Publish(ctx context.Context, metas ...taskmanager.TaskMeta) error {
+	if len(metas) == 0 {
+		return nil
+	}
+
+	return t.insertTasks(ctx, metas)
+}
+
+// Subscribe waits for the next visible task of the queue until the context is done.
+// Database errors are logged and retried on the next poll.
+func (t *Transport) Subscribe(ctx context.Context) (taskmanager.TaskMeta, error) {
+	ticker := time.NewTicker(t.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d, meta, err := t.claimTask(ctx)
+		if err == nil {
+			meta.Delivery = d
+			return meta, nil
+		}
+
+		if ctx.Err() != nil {
+			return taskmanager.TaskMeta{}, ctx.Err()
+		}
+
+		if !errors.Is(err, pgx.ErrNoRows) {
+			t.logger.WithCtx(ctx).
+				WithError(err).
+				With("queue", t.queue).
+				With("id", d.id).
+				Error("failed to claim task")
+		}
+
+		select {
+		case <-ctx.Done():
+			return taskmanager.TaskMeta{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ack removes the task from the queue.
+// The ack of the task redelivered after the visibility timeout is dropped, the new delivery owns the task.
+func (t *Transport) Ack(ctx context.Context, meta taskmanager.TaskMeta) {
+	d, ok := meta.Delivery.(delivery)
+	if !ok {
+		t.logger.WithCtx(ctx).
+			With("task_meta", meta).
+			Error("ack of task not received from postgres transport")
+
+		return
+	}
+
+	if err := t.deleteTask(ctx, d); errors.Is(err, errDeliveryLost) {
+		t.logger.WithCtx(ctx).
+			With("id", d.id).
+			With("attempts", d.attempts).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Warn("ack is dropped, the task is redelivered")
+	} else if err != nil {
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("id", d.id).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Error("failed to ack task")
+	}
+}
+
+// NoAck makes the task visible again, so it is redelivered immediately.
+// The no ack of the task redelivered after the visibility timeout is dropped as well.
+func (t *Transport) NoAck(ctx context.Context, meta taskmanager.TaskMeta) {
+	d, ok := meta.Delivery.(delivery)
+	if !ok {
+		t.logger.WithCtx(ctx).
+			With("task_meta", meta).
+			Error("no ack of task not received from postgres transport")
+
+		return
+	}
+
+	if err := t.releaseTask(ctx, d); errors.Is(err, errDeliveryLost) {
+		t.logger.WithCtx(ctx).
+			With("id", d.id).
+			With("attempts", d.attempts).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Warn("no ack is dropped, the task is redelivered")
+	} else if err != nil {
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("id", d.id).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Error("failed to no ack task")
+	}
+}
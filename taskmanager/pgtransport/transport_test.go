@@ -0,0 +1,144 @@
+package pgtransport
+
+import (
+	"context"
+	"time"
+
+	"github.com/underbek/examples-go/taskmanager"
+)
+
+func (s *TestSuite) newTransport(opts ...Option) *Transport {
+	opts = append([]Option{WithPollInterval(time.Millisecond * 10)}, opts...)
+	return New(s.logger, s.db, "test_queue", opts...)
+}
+
+func (s *TestSuite) Test_Transport_PublishSubscribeAck() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tr := s.newTransport()
+
+	s.Require().NoError(tr.Publish(ctx,
+		taskmanager.TaskMeta{TaskID: "1", FlowID: "flow", RunType: taskmanager.AsyncTask},
+		taskmanager.TaskMeta{TaskID: "2", FlowID: "flow", RunType: taskmanager.AsyncTask, RetryCount: 3},
+	))
+
+	meta, err := tr.Subscribe(ctx)
+	s.Require().NoError(err)
+	s.Equal("1", meta.TaskID)
+	s.Equal("flow", meta.FlowID)
+	s.NotNil(meta.Delivery)
+
+	tr.Ack(ctx, meta)
+
+	meta, err = tr.Subscribe(ctx)
+	s.Require().NoError(err)
+	s.Equal("2", meta.TaskID)
+	s.Equal(3, meta.RetryCount)
+
+	tr.Ack(ctx, meta)
+
+	emptyCtx, emptyCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer emptyCancel()
+
+	_, err = tr.Subscribe(emptyCtx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (s *TestSuite) Test_Transport_NoAck() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tr := s.newTransport()
+
+	s.Require().NoError(tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow"}))
+
+	meta, err := tr.Subscribe(ctx)
+	s.Require().NoError(err)
+
+	tr.NoAck(ctx, meta)
+
+	redelivered, err := tr.Subscribe(ctx)
+	s.Require().NoError(err)
+	s.Equal(meta.TaskID, redelivered.TaskID)
+	s.Equal(meta.Delivery.(delivery).id, redelivered.Delivery.(delivery).id)
+}
+
+func (s *TestSuite) Test_Transport_VisibilityTimeout() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tr := s.newTransport(WithVisibilityTimeout(time.Millisecond * 500))
+	other := s.newTransport(WithVisibilityTimeout(time.Millisecond * 500))
+
+	s.Require().NoError(tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow"}))
+
+	meta, err := tr.Subscribe(ctx)
+	s.Require().NoError(err)
+
+	// the task is hidden from other replicas while it is in flight
+	hiddenCtx, hiddenCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer hiddenCancel()
+
+	_, err = other.Subscribe(hiddenCtx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// and redelivered when the first replica never acks it
+	redelivered, err := other.Subscribe(ctx)
+	s.Require().NoError(err)
+	s.Equal(meta.Delivery.(delivery).id, redelivered.Delivery.(delivery).id)
+}
+
+func (s *TestSuite) Test_Transport_StaleAck() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tr := s.newTransport(WithVisibilityTimeout(time.Millisecond * 200))
+	other := s.newTransport(WithVisibilityTimeout(time.Minute))
+
+	s.Require().NoError(tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow"}))
+
+	stale, err := tr.Subscribe(ctx)
+	s.Require().NoError(err)
+
+	// the task is redelivered to another replica after the visibility timeout
+	redelivered, err := other.Subscribe(ctx)
+	s.Require().NoError(err)
+	s.Equal(stale.Delivery.(delivery).id, redelivered.Delivery.(delivery).id)
+	s.Greater(redelivered.Delivery.(delivery).attempts, stale.Delivery.(delivery).attempts)
+
+	// the late ack and no ack of the first delivery neither delete nor release the task
+	tr.Ack(ctx, stale)
+	tr.NoAck(ctx, stale)
+
+	var count int
+	s.Require().NoError(s.db.QueryRow(ctx, "SELECT COUNT(1) FROM "+defaultTable).Scan(&count))
+	s.Equal(1, count)
+
+	hiddenCtx, hiddenCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer hiddenCancel()
+
+	_, err = tr.Subscribe(hiddenCtx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// the current delivery acks the task
+	other.Ack(ctx, redelivered)
+
+	s.Require().NoError(s.db.QueryRow(ctx, "SELECT COUNT(1) FROM "+defaultTable).Scan(&count))
+	s.Zero(count)
+}
+
+func (s *TestSuite) Test_Transport_NotBefore() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tr := s.newTransport()
+
+	notBefore := time.Now().Add(time.Millisecond * 500)
+	s.Require().NoError(tr.Publish(ctx, taskmanager.TaskMeta{TaskID: "1", FlowID: "flow", NotBefore: &notBefore}))
+
+	meta, err := tr.Subscribe(ctx)
+	s.Require().NoError(err)
+	s.Equal("1", meta.TaskID)
+	s.False(time.Now().Before(notBefore))
+}
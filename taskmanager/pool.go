@@ -2,10 +2,14 @@ package taskmanager
 
 import (
 	"context"
+	"errors"
+	"runtime/debug"
+	"time"
 
+	"github.com/google/uuid"
+	ctxerrors "github.com/underbek/examples-go/errors"
 	"github.com/underbek/examples-go/logger"
-	"github.com/underbek/examples-go/tracing"
-	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/codes"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -16,12 +20,44 @@ type ResultTaskData struct {
 	Result    interface{}
 }
 
+// Pool runs the tasks of the flows. A task is run within TaskSetting.Timeout: on the deadline its context
+// is canceled and the task fails. A task which ignores its context is not stopped, it is left running
+// as a goroutine while the pool goes on, so the task must return on ctx.Done().
 type Pool struct {
 	logger     *logger.Logger
 	creator    TaskCreator
 	publisher  Publisher
 	subscriber Subscriber
 	manager    *FlowManager
+	joinStore  JoinStore
+	runStore   RunStore
+	cancels    *runCancels
+
+	enableMetrics bool
+	metrics       poolMetrics
+}
+
+type PoolOption func(*Pool)
+
+// WithJoinStore Defines the store of joins state, instead of the in-memory one
+func WithJoinStore(store JoinStore) PoolOption {
+	return func(p *Pool) {
+		p.joinStore = store
+	}
+}
+
+// WithRunStore Defines the store of flow runs transitions, transitions are not recorded without it
+func WithRunStore(store RunStore) PoolOption {
+	return func(p *Pool) {
+		p.runStore = store
+	}
+}
+
+// WithMetrics Option to include metrics in Prometheus
+func WithMetrics() PoolOption {
+	return func(p *Pool) {
+		p.enableMetrics = true
+	}
 }
 
 func NewPool(
@@ -30,16 +66,30 @@ func NewPool(
 	publisher Publisher,
 	subscriber Subscriber,
 	manager *FlowManager,
+	opts ...PoolOption,
 ) *Pool {
-	return &Pool{
+	p := &Pool{
 		logger:     logger.Named("task_manager_pool"),
 		creator:    creator,
 		publisher:  publisher,
 		subscriber: subscriber,
 		manager:    manager,
+		joinStore:  NewMemoryJoinStore(),
+		cancels:    newRunCancels(),
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.metrics = newPoolMetrics(p.logger, p.enableMetrics)
+
+	return p
 }
 
+// Run runs the tasks from the subscriber by the size workers until the context is done.
+// A task failed to run is not acknowledged and the worker takes the next one,
+// the pool stops on the failures of the transport only.
 func (p *Pool) Run(ctx context.Context, size int) error {
 	group, ctx := errgroup.WithContext(ctx)
 
@@ -59,7 +109,21 @@ func (p *Pool) Run(ctx context.Context, size int) error {
 					return err
 				}
 
+				p.metrics.setQueueLag(meta, time.Now())
+
 				results, _, err := p.runTask(ctx, meta)
+				if errors.Is(err, ErrFlowNotFound) {
+					// the flow is removed by the reload, its task is never runnable again
+					p.logger.WithCtx(ctx).
+						WithError(err).
+						With("task_meta", meta).
+						Error("drop task of unknown flow")
+
+					p.subscriber.Ack(ctx, meta)
+					continue
+				}
+
+				// the failed task is redelivered by the transport, the other tasks go on
 				if err != nil {
 					p.logger.WithCtx(ctx).
 						WithError(err).
@@ -67,11 +131,11 @@ func (p *Pool) Run(ctx context.Context, size int) error {
 						Error("failed to run task")
 
 					p.subscriber.NoAck(ctx, meta)
-					return err
+					continue
 				}
 
-				p.subscriber.Ack(ctx, meta)
-
+				// successors are published before the ack, so a crash in between
+				// redelivers the task instead of losing the rest of the flow
 				err = p.publisher.Publish(ctx, results...)
 				if err != nil {
 					p.logger.WithCtx(ctx).
@@ -80,8 +144,11 @@ func (p *Pool) Run(ctx context.Context, size int) error {
 						With("flow_id", meta.FlowID).
 						Error("failed to publish")
 
+					p.subscriber.NoAck(ctx, meta)
 					return err
 				}
+
+				p.subscriber.Ack(ctx, meta)
 			}
 		})
 	}
@@ -92,10 +159,75 @@ func (p *Pool) Run(ctx context.Context, size int) error {
 	return resErr
 }
 
+// Publish assigns a new run id to the head of an async flow and publishes it.
+func (p *Pool) Publish(ctx context.Context, head TaskMeta) (string, error) {
+	if head.RunID == "" {
+		head.RunID = uuid.NewString()
+	}
+
+	publishedAt := time.Now()
+	head.PublishedAt = &publishedAt
+
+	if err := p.publisher.Publish(ctx, head); err != nil {
+		p.logger.WithCtx(ctx).
+			WithError(err).
+			With("task_id", head.TaskID).
+			With("flow_id", head.FlowID).
+			With("run_id", head.RunID).
+			Error("failed to publish")
+
+		return "", err
+	}
+
+	return head.RunID, nil
+}
+
+// CancelRun cancels the context of the running tasks of the flow run and stops scheduling their successors.
+// The tasks of the run received later are dropped. Without the run store the cancellation is local to the pool,
+// with it the pools of other replicas check the run status before running a task and scheduling its successors.
+func (p *Pool) CancelRun(ctx context.Context, runID string) error {
+	transition := Transition{
+		RunID:     runID,
+		Type:      TransitionCanceled,
+		CreatedAt: time.Now(),
+	}
+
+	if p.runStore != nil {
+		run, err := p.runStore.GetRun(ctx, runID)
+		if err != nil {
+			return err
+		}
+
+		transition.FlowID = run.FlowID
+		transition.TaskID = run.CurrentTask
+	}
+
+	p.cancels.cancel(runID)
+
+	p.logger.WithCtx(ctx).
+		With("run_id", runID).
+		Info("flow run canceled")
+
+	if p.runStore != nil {
+		if err := p.runStore.AddTransition(ctx, transition); err != nil {
+			p.logger.WithCtx(ctx).
+				WithError(err).
+				With("transition", transition).
+				Error("failed to add transition")
+		}
+	}
+
+	return nil
+}
+
 func (p *Pool) RunSync(ctx context.Context, head TaskMeta) ([]ResultTaskData, error) {
 	metas := make(chan TaskMeta, 10)
 	defer close(metas)
 
+	if head.RunID == "" {
+		head.RunID = uuid.NewString()
+	}
+
 	metas <- head
 
 	var results []ResultTaskData
@@ -104,6 +236,10 @@ func (p *Pool) RunSync(ctx context.Context, head TaskMeta) ([]ResultTaskData, er
 		default:
 			return results, nil
 		case meta := <-metas:
+			if err := WaitNotBefore(ctx, meta); err != nil {
+				return nil, err
+			}
+
 			newMetas, result, err := p.runTask(ctx, meta)
 			if err != nil {
 				p.logger.WithCtx(ctx).
@@ -144,30 +280,31 @@ func (p *Pool) RunSync(ctx context.Context, head TaskMeta) ([]ResultTaskData, er
 }
 
 func (p *Pool) runTask(ctx context.Context, meta TaskMeta) ([]TaskMeta, ResultTaskData, error) {
-	if meta.Trace != nil {
-		ctx = tracing.PutTraceInfoIntoContext(ctx, meta.Trace.TraceID, meta.Trace.SpanID)
-	}
-
-	ctx, span := tracing.StartCustomSpan(ctx, trace.SpanKindServer, "task_manager", "RunSync")
+	ctx, span := startTaskSpan(ctx, meta)
 	defer span.End()
 
-	task, err := p.creator.Create(meta)
+	// the head of an async flow gets its run id from the first worker
+	if meta.RunID == "" {
+		meta.RunID = uuid.NewString()
+	}
+
+	flow, err := p.manager.GetFlow(meta.FlowID)
 	if err != nil {
 		p.logger.WithCtx(ctx).
 			WithError(err).
-			With("task_id", meta.TaskID).
 			With("flow_id", meta.FlowID).
-			Error("failed to create task")
+			Error("failed to get flow")
 
 		return nil, ResultTaskData{}, err
 	}
 
-	flow, err := p.manager.GetFlow(meta.FlowID)
+	task, err := p.creator.Create(meta)
 	if err != nil {
 		p.logger.WithCtx(ctx).
 			WithError(err).
+			With("task_id", meta.TaskID).
 			With("flow_id", meta.FlowID).
-			Error("failed to get flow")
+			Error("failed to create task")
 
 		return nil, ResultTaskData{}, err
 	}
@@ -179,20 +316,98 @@ func (p *Pool) runTask(ctx context.Context, meta TaskMeta) ([]TaskMeta, ResultTa
 		With("flow_id", meta.FlowID).
 		Debug("run task")
 
-	result, err := task.Run(ctx, meta)
+	if err = p.checkRunCanceled(ctx, meta.RunID); err != nil {
+		p.logger.WithCtx(ctx).
+			WithError(err).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			With("run_id", meta.RunID).
+			Error("failed to check run")
+
+		return nil, ResultTaskData{}, err
+	}
+
+	taskCtx, release, ok := p.cancels.start(ctx, meta.RunID)
+	if !ok {
+		p.logger.WithCtx(ctx).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			With("run_id", meta.RunID).
+			Info("skip task of canceled run")
+
+		return nil, ResultTaskData{Meta: meta, Condition: FailCondition, Err: ErrRunCanceled}, nil
+	}
+
+	defer release()
+
+	p.addTransition(ctx, meta, TransitionStarted, nil)
+
+	start := time.Now()
+	result, err := p.execute(taskCtx, task, meta)
+	duration := time.Since(start)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	// the successors are still scheduled when the run can not be checked
+	if checkErr := p.checkRunCanceled(ctx, meta.RunID); checkErr != nil {
+		p.logger.WithCtx(ctx).
+			WithError(checkErr).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			With("run_id", meta.RunID).
+			Error("failed to check run")
+	}
+
+	if p.cancels.isCanceled(meta.RunID) {
+		p.logger.WithCtx(ctx).
+			WithError(err).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			With("run_id", meta.RunID).
+			Info("stop canceled run")
+
+		if err != nil {
+			condition = FailCondition
+		}
+
+		return nil,
+			ResultTaskData{
+				Meta:      meta,
+				Condition: condition,
+				Err:       err,
+				Result:    result,
+			},
+			nil
+	}
+
 	if err != nil {
 		meta.FailCount++
 		condition = FailCondition
 
-		if meta.FailCount < meta.RetryCount {
+		if meta.FailCount < meta.RetryCount && meta.RetryPolicy.IsRetryable(err) {
+			meta.NotBefore = nil
+
+			delay := meta.RetryPolicy.Backoff(meta.FailCount)
+			if delay > 0 {
+				notBefore := time.Now().Add(delay)
+				meta.NotBefore = &notBefore
+			}
+
 			p.logger.WithCtx(ctx).
 				WithError(err).
 				With("task_id", meta.TaskID).
 				With("flow_id", meta.FlowID).
 				With("fail_count", meta.FailCount).
 				With("retry_count", meta.RetryCount).
+				With("retry_delay", delay).
 				Info("retry task after fail")
 
+			p.addTransition(ctx, meta, TransitionRetried, err)
+			p.metrics.registerTaskDuration(meta, TransitionRetried, duration)
+
 			return []TaskMeta{meta},
 				ResultTaskData{
 					Meta:      meta,
@@ -212,16 +427,51 @@ func (p *Pool) runTask(ctx context.Context, meta TaskMeta) ([]TaskMeta, ResultTa
 		With("retry_count", meta.RetryCount).
 		Error("task failed")
 
+	if err != nil {
+		p.addTransition(ctx, meta, TransitionFailed, err)
+		p.metrics.registerTaskDuration(meta, TransitionFailed, duration)
+	} else {
+		p.addTransition(ctx, meta, TransitionSucceeded, nil)
+		p.metrics.registerTaskDuration(meta, TransitionSucceeded, duration)
+	}
+
 	newTasks := flow.GetTasks(meta.TaskID, condition)
 	for i := range newTasks {
 		newTasks[i].PreviousResult = result
+		newTasks[i].PreviousTaskID = meta.TaskID
+	}
 
-		if span.IsRecording() {
-			traceData := &TaskTrace{
-				TraceID: tracing.GetSpanContextFromContext(ctx).TraceID(),
-				SpanID:  tracing.GetSpanContextFromContext(ctx).SpanID(),
-			}
-			newTasks[i].Trace = traceData
+	joinTasks, joinErr := p.arriveJoins(ctx, flow, meta, condition, result, passCompensations(meta, result, err))
+	if joinErr != nil {
+		return nil, ResultTaskData{}, joinErr
+	}
+
+	compensations, compensator := compensate(meta, len(newTasks)+len(joinTasks) != 0, result, err)
+	for i := range newTasks {
+		newTasks[i].Compensations = compensations
+	}
+
+	// the joins continue with the merged steps of their parents
+	newTasks = append(newTasks, joinTasks...)
+
+	if compensator != nil {
+		p.logger.WithCtx(ctx).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			With("compensator_id", compensator.TaskID).
+			With("compensates", compensator.Compensates).
+			Info("compensate step")
+
+		newTasks = append(newTasks, *compensator)
+	}
+
+	publishedAt := time.Now()
+	for i := range newTasks {
+		newTasks[i].RunID = meta.RunID
+		newTasks[i].Trace = NewTaskTrace(ctx)
+
+		if newTasks[i].RunType == AsyncTask {
+			newTasks[i].PublishedAt = &publishedAt
 		}
 	}
 
@@ -234,3 +484,129 @@ func (p *Pool) runTask(ctx context.Context, meta TaskMeta) ([]TaskMeta, ResultTa
 		},
 		nil
 }
+
+// execute runs the task within its timeout, a panic of the task is recovered into an error.
+func (p *Pool) execute(ctx context.Context, task Task, meta TaskMeta) (interface{}, error) {
+	if meta.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, meta.Timeout)
+		defer cancel()
+	}
+
+	type output struct {
+		result interface{}
+		err    error
+	}
+
+	done := make(chan output, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				p.logger.WithCtx(ctx).
+					With("task_id", meta.TaskID).
+					With("flow_id", meta.FlowID).
+					With("panic", r).
+					With("stack", string(debug.Stack())).
+					Error("task panicked")
+
+				done <- output{err: ctxerrors.Errorf(ctxerrors.TypeInternal, "task %s panicked: %v", meta.TaskID, r)}
+			}
+		}()
+
+		result, err := task.Run(ctx, meta)
+		done <- output{result: result, err: err}
+	}()
+
+	var out output
+
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		// the task ignoring its context is left behind, so it does not hold the worker
+		select {
+		case out = <-done:
+		default:
+			out.err = ctx.Err()
+		}
+	}
+
+	// the task honoring its context returns ctx.Err() at the same moment the deadline fires,
+	// whichever branch is selected the timeout is reported the same way
+	if out.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, ctxerrors.Wrapf(out.err, ctxerrors.TypeInternal, "task %s timed out after %s", meta.TaskID, meta.Timeout)
+	}
+
+	return out.result, out.err
+}
+
+func (p *Pool) arriveJoins(
+	ctx context.Context,
+	flow *Flow,
+	meta TaskMeta,
+	condition ConditionType,
+	result interface{},
+	compensations []Compensation,
+) ([]TaskMeta, error) {
+	var metas []TaskMeta
+
+	for _, join := range flow.GetJoins(meta.TaskID, condition) {
+		results, ready, err := p.joinStore.Arrive(ctx, JoinArrival{
+			RunID:         meta.RunID,
+			JoinID:        join.Task.TaskID,
+			ParentID:      meta.TaskID,
+			Result:        result,
+			Compensations: compensations,
+			Required:      join.required(),
+			Total:         len(join.Parents),
+		})
+		if err != nil {
+			p.logger.WithCtx(ctx).
+				WithError(err).
+				With("task_id", meta.TaskID).
+				With("flow_id", meta.FlowID).
+				With("run_id", meta.RunID).
+				With("join_id", join.Task.TaskID).
+				Error("failed to arrive join")
+
+			return nil, err
+		}
+
+		if !ready {
+			continue
+		}
+
+		metas = append(metas, flow.newJoinMeta(join, results))
+	}
+
+	return metas, nil
+}
+
+func (p *Pool) addTransition(ctx context.Context, meta TaskMeta, transitionType TransitionType, taskErr error) {
+	p.metrics.incTask(meta, transitionType)
+
+	if p.runStore == nil {
+		return
+	}
+
+	transition := Transition{
+		RunID:     meta.RunID,
+		FlowID:    meta.FlowID,
+		TaskID:    meta.TaskID,
+		Type:      transitionType,
+		FailCount: meta.FailCount,
+		CreatedAt: time.Now(),
+	}
+
+	if taskErr != nil {
+		transition.Error = taskErr.Error()
+	}
+
+	// the run history must not break the flow itself
+	if err := p.runStore.AddTransition(ctx, transition); err != nil {
+		p.logger.WithCtx(ctx).
+			WithError(err).
+			With("transition", transition).
+			Error("failed to add transition")
+	}
+}
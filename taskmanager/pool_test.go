@@ -9,11 +9,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
 	"github.com/underbek/examples-go/logger"
 )
 
 var (
-	errTest = errors.New("test error")
+	errTest        = errors.New("test error")
+	errTestInvalid = ctxerrors.New(ctxerrors.TypeInvalidRequest, "test invalid error")
 )
 
 type TestTask struct {
@@ -47,6 +49,15 @@ func (t *TestTaskFailed) Run(ctx context.Context, meta TaskMeta) (interface{}, e
 	return result, errTest
 }
 
+type TestTaskInvalid struct {
+	TestTask
+}
+
+func (t *TestTaskInvalid) Run(ctx context.Context, meta TaskMeta) (interface{}, error) {
+	result, _ := t.TestTask.Run(ctx, meta)
+	return result, errTestInvalid
+}
+
 type TestCreator struct {
 	completeCount int
 }
@@ -67,6 +78,8 @@ func (c *TestCreator) Create(meta TaskMeta) (Task, error) {
 		return &TestTaskSuccess{testTask}, nil
 	case "4":
 		return &TestTaskFailed{testTask}, nil
+	case "5":
+		return &TestTaskInvalid{testTask}, nil
 	}
 
 	return nil, errors.New("not implemented")
@@ -192,8 +205,14 @@ func TestPool_RunSync(t *testing.T) {
 	assert.NoError(t, err)
 	require.Len(t, results, 6)
 
+	runID := results[0].Meta.RunID
+	assert.NotEmpty(t, runID)
+
 	for i := range results {
+		assert.Equal(t, runID, results[i].Meta.RunID)
+
 		results[i].Meta.Trace = nil
+		results[i].Meta.RunID = ""
 	}
 
 	assert.Equal(t, TaskMeta{
@@ -209,6 +228,7 @@ func TestPool_RunSync(t *testing.T) {
 		FlowID:         "flow_1",
 		RunType:        SyncTask,
 		PreviousResult: []string{"head"},
+		PreviousTaskID: "head",
 	}, results[1].Meta)
 	assert.Equal(t, SuccessCondition, results[1].Condition)
 	assert.Equal(t, []string{"head", "1"}, results[1].Result)
@@ -218,6 +238,7 @@ func TestPool_RunSync(t *testing.T) {
 		FlowID:         "flow_1",
 		RunType:        SyncTask,
 		PreviousResult: []string{"head", "1"},
+		PreviousTaskID: "1",
 	}, results[2].Meta)
 	assert.Equal(t, SuccessCondition, results[2].Condition)
 	assert.Equal(t, []string{"head", "1", "3"}, results[2].Result)
@@ -229,6 +250,7 @@ func TestPool_RunSync(t *testing.T) {
 		RetryCount:     3,
 		FailCount:      1,
 		PreviousResult: []string{"head", "1", "3"},
+		PreviousTaskID: "3",
 	}, results[3].Meta)
 	assert.Equal(t, FailCondition, results[3].Condition)
 	assert.Equal(t, []string{"head", "1", "3", "4"}, results[3].Result)
@@ -241,6 +263,7 @@ func TestPool_RunSync(t *testing.T) {
 		RetryCount:     3,
 		FailCount:      2,
 		PreviousResult: []string{"head", "1", "3"},
+		PreviousTaskID: "3",
 	}, results[4].Meta)
 	assert.Equal(t, FailCondition, results[4].Condition)
 	assert.Equal(t, []string{"head", "1", "3", "4"}, results[4].Result)
@@ -253,6 +276,7 @@ func TestPool_RunSync(t *testing.T) {
 		RetryCount:     3,
 		FailCount:      3,
 		PreviousResult: []string{"head", "1", "3"},
+		PreviousTaskID: "3",
 	}, results[5].Meta)
 	assert.Equal(t, FailCondition, results[5].Condition)
 	assert.Equal(t, []string{"head", "1", "3", "4"}, results[5].Result)
@@ -268,3 +292,62 @@ func TestPool_RunSync(t *testing.T) {
 		return errors.Is(runError, ErrChannelClosed)
 	}, time.Second*5, time.Millisecond*10)
 }
+
+func TestPool_RunSyncRetryPolicy(t *testing.T) {
+	flow := NewFlow("flow_1")
+	headTask := TaskMeta{
+		FlowID:  "flow_1",
+		TaskID:  "head",
+		RunType: SyncTask,
+	}
+	task4 := TaskSetting{
+		TaskID:      "4",
+		RunType:     SyncTask,
+		RetryCount:  3,
+		RetryPolicy: &RetryPolicy{InitialInterval: time.Millisecond * 20},
+	}
+	task5 := TaskSetting{
+		TaskID:      "5",
+		RunType:     SyncTask,
+		RetryCount:  3,
+		RetryPolicy: &RetryPolicy{NonRetryable: []ctxerrors.Type{ctxerrors.TypeInvalidRequest}},
+	}
+
+	flow.AddCondition("head", SuccessCondition, task4, task5)
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, &TestCreator{}, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	start := time.Now()
+
+	results, err := pool.RunSync(ctx, headTask)
+	require.NoError(t, err)
+	require.Len(t, results, 5) // head-1, task4-3, task5-1
+
+	// 20ms before the second try of task 4 and 40ms before the third one
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*60)
+
+	assert.Equal(t, "4", results[1].Meta.TaskID)
+	assert.NotNil(t, results[1].Meta.NotBefore)
+
+	assert.Equal(t, "5", results[2].Meta.TaskID)
+	assert.Equal(t, 1, results[2].Meta.FailCount)
+	assert.Equal(t, errTestInvalid, results[2].Err)
+
+	for _, result := range results[3:] {
+		assert.Equal(t, "4", result.Meta.TaskID)
+		assert.Equal(t, FailCondition, result.Condition)
+	}
+	assert.Equal(t, 3, results[4].Meta.FailCount)
+}
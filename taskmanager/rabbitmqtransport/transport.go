@@ -0,0 +1,189 @@
+package rabbitmqtransport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/taskmanager"
+	"github.com/underbek/examples-go/transport/rabbitmq"
+)
+
+var _ taskmanager.Publisher = (*Transport)(nil)
+var _ taskmanager.Subscriber = (*Transport)(nil)
+
+// Transport is a taskmanager.Publisher and taskmanager.Subscriber on top of rabbitmq.
+// TaskMeta is sent as a json message body, trace and logger meta are carried in the message headers.
+// Ack acknowledges the delivery, NoAck rejects it with requeue.
+type Transport struct {
+	logger     *logger.Logger
+	producer   *rabbitmq.Producer
+	consumer   *rabbitmq.Consumer
+	routingKey string
+	consume    rabbitmq.Consume
+
+	deliveries chan taskmanager.TaskMeta
+	start      sync.Once
+	done       chan struct{}
+	err        error
+}
+
+func New(
+	logger *logger.Logger,
+	producer *rabbitmq.Producer,
+	consumer *rabbitmq.Consumer,
+	routingKey string,
+	consume rabbitmq.Consume,
+) *Transport {
+	// deliveries are acknowledged by the pool after the task is done
+	consume.NoAck = false
+
+	return &Transport{
+		logger:     logger.Named("task_manager_rabbitmq_transport"),
+		producer:   producer,
+		consumer:   consumer,
+		routingKey: routingKey,
+		consume:    consume,
+		deliveries: make(chan taskmanager.TaskMeta),
+		done:       make(chan struct{}),
+	}
+}
+
+func (t *Transport) Publish(ctx context.Context, metas ...taskmanager.TaskMeta) error {
+	for _, meta := range metas {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("marshal task meta: %w", err)
+		}
+
+		// the producer injects the trace and the logger meta of the context into the headers
+		msgCtx := taskmanager.PutTaskTraceIntoContext(ctx, meta.Trace)
+
+		err = t.producer.Publish(msgCtx, rabbitmq.PublishMessage{
+			RoutingKey: t.routingKey,
+			Message: amqp.Publishing{
+				ContentType:  "application/json",
+				DeliveryMode: amqp.Persistent,
+				Body:         data,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Subscribe starts consuming with the context of the first call and waits for the next task.
+func (t *Transport) Subscribe(ctx context.Context) (taskmanager.TaskMeta, error) {
+	t.start.Do(func() {
+		go func() {
+			t.err = t.consumer.Consume(ctx, t.consume, t.handle)
+			close(t.done)
+		}()
+	})
+
+	select {
+	case <-ctx.Done():
+		return taskmanager.TaskMeta{}, ctx.Err()
+	case <-t.done:
+		return taskmanager.TaskMeta{}, fmt.Errorf("consume stopped: %w", t.err)
+	case meta := <-t.deliveries:
+		return meta, nil
+	}
+}
+
+func (t *Transport) Ack(ctx context.Context, meta taskmanager.TaskMeta) {
+	delivery, ok := meta.Delivery.(amqp.Delivery)
+	if !ok {
+		t.logger.WithCtx(ctx).
+			With("task_meta", meta).
+			Error("ack of task not received from rabbitmq transport")
+
+		return
+	}
+
+	if err := delivery.Ack(false); err != nil {
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Error("failed to ack task")
+	}
+}
+
+func (t *Transport) NoAck(ctx context.Context, meta taskmanager.TaskMeta) {
+	delivery, ok := meta.Delivery.(amqp.Delivery)
+	if !ok {
+		t.logger.WithCtx(ctx).
+			With("task_meta", meta).
+			Error("no ack of task not received from rabbitmq transport")
+
+		return
+	}
+
+	if err := delivery.Nack(false, true); err != nil {
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("task_id", meta.TaskID).
+			With("flow_id", meta.FlowID).
+			Error("failed to no ack task")
+	}
+}
+
+func (t *Transport) handle(ctx context.Context, msg amqp.Delivery) {
+	var meta taskmanager.TaskMeta
+	if err := json.Unmarshal(msg.Body, &meta); err != nil {
+		t.logger.WithCtx(ctx).
+			WithError(err).
+			With("routing_key", msg.RoutingKey).
+			Error("failed to unmarshal task meta, message rejected")
+
+		if err = msg.Reject(false); err != nil {
+			t.logger.WithCtx(ctx).WithError(err).Error("failed to reject message")
+		}
+
+		return
+	}
+
+	if taskTrace := taskmanager.NewTaskTrace(ctx); taskTrace != nil {
+		meta.Trace = taskTrace
+	}
+
+	meta.Delivery = msg
+
+	if meta.NotBefore != nil && time.Until(*meta.NotBefore) > 0 {
+		// the postponed delivery stays unacked and does not block the following ones
+		go t.deliver(ctx, meta, msg)
+		return
+	}
+
+	t.deliver(ctx, meta, msg)
+}
+
+func (t *Transport) deliver(ctx context.Context, meta taskmanager.TaskMeta, msg amqp.Delivery) {
+	err := taskmanager.WaitNotBefore(ctx, meta)
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case t.deliveries <- meta:
+			return
+		}
+	}
+
+	t.logger.WithCtx(ctx).
+		WithError(err).
+		With("task_id", meta.TaskID).
+		With("flow_id", meta.FlowID).
+		Debug("consume stopped, message requeued")
+
+	if err = msg.Nack(false, true); err != nil {
+		t.logger.WithCtx(ctx).WithError(err).Error("failed to requeue message")
+	}
+}
@@ -0,0 +1,118 @@
+package rabbitmqtransport
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/taskmanager"
+	"github.com/underbek/examples-go/transport/rabbitmq"
+)
+
+type testAcknowledger struct {
+	mtx      sync.Mutex
+	acked    []uint64
+	requeued []uint64
+	rejected []uint64
+}
+
+func (a *testAcknowledger) Ack(tag uint64, _ bool) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *testAcknowledger) Nack(tag uint64, _ bool, requeue bool) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if requeue {
+		a.requeued = append(a.requeued, tag)
+	} else {
+		a.rejected = append(a.rejected, tag)
+	}
+	return nil
+}
+
+func (a *testAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestTransport_AckNoAck(t *testing.T) {
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	acknowledger := &testAcknowledger{}
+	deliveries := make(chan amqp.Delivery, 10)
+	tag := uint64(0)
+
+	ch := rabbitmq.NewChannelMock(t)
+	ch.On("PublishWithContext", mock.Anything, "exchange", "routing_key", false, false, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(5).(amqp.Publishing)
+			assert.Equal(t, "application/json", msg.ContentType)
+
+			tag++
+			deliveries <- amqp.Delivery{
+				Acknowledger: acknowledger,
+				DeliveryTag:  tag,
+				Headers:      msg.Headers,
+				Body:         msg.Body,
+			}
+		}).
+		Return(nil)
+	ch.On("Consume", "queue", "", false, false, false, false, amqp.Table(nil)).
+		Return((<-chan amqp.Delivery)(deliveries), nil).
+		Once()
+
+	tr := New(
+		lg,
+		rabbitmq.NewProducer(lg, ch, "exchange"),
+		rabbitmq.NewConsumer(lg, ch, "queue", false),
+		"routing_key",
+		rabbitmq.Consume{NoAck: true},
+	)
+
+	err = tr.Publish(ctx,
+		taskmanager.TaskMeta{TaskID: "1", FlowID: "flow", RunType: taskmanager.AsyncTask},
+		taskmanager.TaskMeta{TaskID: "2", FlowID: "flow", RunType: taskmanager.AsyncTask},
+	)
+	require.NoError(t, err)
+
+	deliveries <- amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 100, Body: []byte("broken")}
+
+	meta, err := tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", meta.TaskID)
+	tr.Ack(ctx, meta)
+
+	meta, err = tr.Subscribe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2", meta.TaskID)
+	tr.NoAck(ctx, meta)
+
+	assert.Eventually(t, func() bool {
+		acknowledger.mtx.Lock()
+		defer acknowledger.mtx.Unlock()
+
+		return len(acknowledger.rejected) == 1
+	}, time.Second, time.Millisecond*10)
+
+	acknowledger.mtx.Lock()
+	defer acknowledger.mtx.Unlock()
+
+	assert.Equal(t, []uint64{1}, acknowledger.acked)
+	assert.Equal(t, []uint64{2}, acknowledger.requeued)
+	assert.Equal(t, []uint64{100}, acknowledger.rejected)
+}
@@ -0,0 +1,83 @@
+package taskmanager
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/utils"
+)
+
+const defaultBackoffMultiplier = 2
+
+// RetryPolicy describes the delay between retries of a failed task and which errors are not worth retrying.
+// A nil policy retries immediately on any error.
+type RetryPolicy struct {
+	// InitialInterval is a delay before the first retry
+	InitialInterval time.Duration `json:"initial_interval,omitempty"`
+	// MaxInterval caps the delay, zero means no cap
+	MaxInterval time.Duration `json:"max_interval,omitempty"`
+	// Multiplier grows the delay after each retry, 2 by default
+	Multiplier float64 `json:"multiplier,omitempty"`
+	// Jitter randomizes the delay by the given fraction, 0.2 means ±20%
+	Jitter float64 `json:"jitter,omitempty"`
+	// NonRetryable lists error types that fail the task without retries
+	NonRetryable []ctxerrors.Type `json:"non_retryable,omitempty"`
+}
+
+// IsRetryable reports whether the task failed with err may be retried.
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if p == nil || err == nil {
+		return true
+	}
+
+	return !utils.Contains(p.NonRetryable, ctxerrors.ErrorType(err))
+}
+
+// Backoff returns the delay before the given retry, the first retry is 1.
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	if p == nil || p.InitialInterval <= 0 || retry < 1 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	delay := float64(p.InitialInterval) * math.Pow(multiplier, float64(retry-1))
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1) //nolint:gosec
+	}
+
+	if p.MaxInterval > 0 && delay > float64(p.MaxInterval) {
+		delay = float64(p.MaxInterval)
+	}
+
+	return time.Duration(delay)
+}
+
+// WaitNotBefore blocks until the task is allowed to run or the context is done.
+func WaitNotBefore(ctx context.Context, meta TaskMeta) error {
+	if meta.NotBefore == nil {
+		return nil
+	}
+
+	delay := time.Until(*meta.NotBefore)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package taskmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{
+			name:   "nil policy",
+			policy: nil,
+			retry:  3,
+			want:   0,
+		},
+		{
+			name:   "first retry",
+			policy: &RetryPolicy{InitialInterval: time.Second},
+			retry:  1,
+			want:   time.Second,
+		},
+		{
+			name:   "default multiplier",
+			policy: &RetryPolicy{InitialInterval: time.Second},
+			retry:  3,
+			want:   time.Second * 4,
+		},
+		{
+			name:   "custom multiplier",
+			policy: &RetryPolicy{InitialInterval: time.Second, Multiplier: 3},
+			retry:  3,
+			want:   time.Second * 9,
+		},
+		{
+			name:   "capped",
+			policy: &RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Second * 5},
+			retry:  10,
+			want:   time.Second * 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Backoff(tt.retry))
+		})
+	}
+}
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	policy := &RetryPolicy{InitialInterval: time.Second, Jitter: 0.5, MaxInterval: time.Second * 3}
+
+	for i := 0; i < 100; i++ {
+		delay := policy.Backoff(2)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, time.Second*3)
+	}
+}
+
+func TestRetryPolicy_IsRetryable(t *testing.T) {
+	policy := &RetryPolicy{NonRetryable: []ctxerrors.Type{ctxerrors.TypeInvalidRequest}}
+
+	assert.True(t, (*RetryPolicy)(nil).IsRetryable(errTest))
+	assert.True(t, policy.IsRetryable(errTest))
+	assert.True(t, policy.IsRetryable(ctxerrors.New(ctxerrors.TypeExternal, "external")))
+	assert.False(t, policy.IsRetryable(ctxerrors.New(ctxerrors.TypeInvalidRequest, "invalid")))
+	assert.False(t, policy.IsRetryable(fmt.Errorf("wrapped: %w", ctxerrors.New(ctxerrors.TypeInvalidRequest, "invalid"))))
+}
+
+func TestWaitNotBefore(t *testing.T) {
+	notBefore := time.Now().Add(time.Millisecond * 50)
+
+	start := time.Now()
+	assert.NoError(t, WaitNotBefore(context.Background(), TaskMeta{NotBefore: &notBefore}))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*50)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	notBefore = time.Now().Add(time.Hour)
+	assert.True(t, errors.Is(WaitNotBefore(ctx, TaskMeta{NotBefore: &notBefore}), context.Canceled))
+	assert.NoError(t, WaitNotBefore(ctx, TaskMeta{}))
+}
@@ -0,0 +1,155 @@
+package taskmanager
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+type TransitionType string
+
+const (
+	TransitionStarted   TransitionType = "started"
+	TransitionSucceeded TransitionType = "succeeded"
+	TransitionFailed    TransitionType = "failed"
+	TransitionRetried   TransitionType = "retried"
+	TransitionCanceled  TransitionType = "canceled"
+)
+
+// Transition is a change of the task state inside a flow run.
+type Transition struct {
+	RunID     string         `json:"run_id" db:"run_id"`
+	FlowID    string         `json:"flow_id" db:"flow_id"`
+	TaskID    string         `json:"task_id" db:"task_id"`
+	Type      TransitionType `json:"type" db:"type"`
+	FailCount int            `json:"fail_count" db:"fail_count"`
+	Error     string         `json:"error,omitempty" db:"error"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Run is a flow execution with its latest transition.
+type Run struct {
+	RunID       string         `json:"run_id" db:"run_id"`
+	FlowID      string         `json:"flow_id" db:"flow_id"`
+	CurrentTask string         `json:"current_task" db:"current_task"`
+	Status      TransitionType `json:"status" db:"status"`
+	StartedAt   time.Time      `json:"started_at" db:"started_at"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+type RunFilter struct {
+	FlowID string
+	Status TransitionType
+	Limit  int
+	Offset int
+}
+
+// RunStore persists the transitions of flow runs and answers what happened to them.
+// The canceled run keeps its status, the later transitions are recorded in its history only.
+type RunStore interface {
+	AddTransition(context.Context, Transition) error
+	// GetRuns returns the runs matching the filter, the latest started first
+	GetRuns(context.Context, RunFilter) ([]Run, error)
+	GetRun(context.Context, string) (Run, error)
+	// GetHistory returns all transitions of the run in order
+	GetHistory(context.Context, string) ([]Transition, error)
+}
+
+// MemoryRunStore keeps the runs in memory of the process, it is meant for tests and local runs.
+type MemoryRunStore struct {
+	runs    map[string]*Run
+	history map[string][]Transition
+	mtx     sync.RWMutex
+}
+
+func NewMemoryRunStore() *MemoryRunStore {
+	return &MemoryRunStore{
+		runs:    make(map[string]*Run),
+		history: make(map[string][]Transition),
+	}
+}
+
+func (s *MemoryRunStore) AddTransition(_ context.Context, transition Transition) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	run, ok := s.runs[transition.RunID]
+	if !ok {
+		run = &Run{
+			RunID:     transition.RunID,
+			FlowID:    transition.FlowID,
+			StartedAt: transition.CreatedAt,
+		}
+		s.runs[transition.RunID] = run
+	}
+
+	if run.Status != TransitionCanceled {
+		run.CurrentTask = transition.TaskID
+		run.Status = transition.Type
+		run.UpdatedAt = transition.CreatedAt
+	}
+
+	s.history[transition.RunID] = append(s.history[transition.RunID], transition)
+
+	return nil
+}
+
+func (s *MemoryRunStore) GetRuns(_ context.Context, filter RunFilter) ([]Run, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	runs := make([]Run, 0, len(s.runs))
+	for _, run := range s.runs {
+		if filter.FlowID != "" && run.FlowID != filter.FlowID {
+			continue
+		}
+
+		if filter.Status != "" && run.Status != filter.Status {
+			continue
+		}
+
+		runs = append(runs, *run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+
+	if filter.Offset >= len(runs) {
+		return nil, nil
+	}
+
+	runs = runs[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(runs) {
+		runs = runs[:filter.Limit]
+	}
+
+	return runs, nil
+}
+
+func (s *MemoryRunStore) GetRun(_ context.Context, runID string) (Run, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	run, ok := s.runs[runID]
+	if !ok {
+		return Run{}, ctxerrors.Errorf(ctxerrors.TypeNotFound, "run with id %s is not exists", runID)
+	}
+
+	return *run, nil
+}
+
+func (s *MemoryRunStore) GetHistory(_ context.Context, runID string) ([]Transition, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	history, ok := s.history[runID]
+	if !ok {
+		return nil, ctxerrors.Errorf(ctxerrors.TypeNotFound, "run with id %s is not exists", runID)
+	}
+
+	return append([]Transition(nil), history...), nil
+}
@@ -0,0 +1,158 @@
+package taskmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+)
+
+func TestMemoryRunStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRunStore()
+	now := time.Now()
+
+	transitions := []Transition{
+		{RunID: "run_1", FlowID: "flow_1", TaskID: "head", Type: TransitionStarted, CreatedAt: now},
+		{RunID: "run_2", FlowID: "flow_2", TaskID: "head", Type: TransitionStarted, CreatedAt: now.Add(time.Second)},
+		{RunID: "run_1", FlowID: "flow_1", TaskID: "head", Type: TransitionSucceeded, CreatedAt: now.Add(time.Second)},
+		{RunID: "run_3", FlowID: "flow_1", TaskID: "head", Type: TransitionStarted, CreatedAt: now.Add(time.Second * 2)},
+	}
+
+	for _, transition := range transitions {
+		require.NoError(t, store.AddTransition(ctx, transition))
+	}
+
+	run, err := store.GetRun(ctx, "run_1")
+	require.NoError(t, err)
+	assert.Equal(t, Run{
+		RunID:       "run_1",
+		FlowID:      "flow_1",
+		CurrentTask: "head",
+		Status:      TransitionSucceeded,
+		StartedAt:   now,
+		UpdatedAt:   now.Add(time.Second),
+	}, run)
+
+	history, err := store.GetHistory(ctx, "run_1")
+	require.NoError(t, err)
+	assert.Equal(t, []Transition{transitions[0], transitions[2]}, history)
+
+	runs, err := store.GetRuns(ctx, RunFilter{})
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, "run_3", runs[0].RunID)
+	assert.Equal(t, "run_1", runs[2].RunID)
+
+	runs, err = store.GetRuns(ctx, RunFilter{FlowID: "flow_1", Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "run_1", runs[0].RunID)
+
+	runs, err = store.GetRuns(ctx, RunFilter{Status: TransitionStarted})
+	require.NoError(t, err)
+	assert.Len(t, runs, 2)
+
+	_, err = store.GetRun(ctx, "unknown")
+	assert.Equal(t, ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+
+	_, err = store.GetHistory(ctx, "unknown")
+	assert.Equal(t, ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+
+	// the canceled run keeps its status
+	canceled := Transition{RunID: "run_3", FlowID: "flow_1", TaskID: "head", Type: TransitionCanceled, CreatedAt: now.Add(time.Second * 3)}
+	require.NoError(t, store.AddTransition(ctx, canceled))
+	require.NoError(t, store.AddTransition(ctx, Transition{
+		RunID:     "run_3",
+		FlowID:    "flow_1",
+		TaskID:    "next",
+		Type:      TransitionSucceeded,
+		CreatedAt: now.Add(time.Second * 4),
+	}))
+
+	run, err = store.GetRun(ctx, "run_3")
+	require.NoError(t, err)
+	assert.Equal(t, TransitionCanceled, run.Status)
+	assert.Equal(t, "head", run.CurrentTask)
+
+	history, err = store.GetHistory(ctx, "run_3")
+	require.NoError(t, err)
+	assert.Len(t, history, 3)
+}
+
+func TestPool_RunStore(t *testing.T) {
+	flow := NewFlow("flow_1")
+	headTask := TaskMeta{
+		FlowID:  "flow_1",
+		TaskID:  "head",
+		RunType: AsyncTask,
+	}
+
+	flow.AddCondition("head", SuccessCondition, TaskSetting{
+		TaskID:     "2",
+		RunType:    AsyncTask,
+		RetryCount: 2,
+	})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	store := NewMemoryRunStore()
+	pool := NewPool(lg, &TestCreator{}, transport, transport, manager, WithRunStore(store))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		_ = pool.Run(ctx, 1)
+	}()
+
+	runID, err := pool.Publish(ctx, headTask)
+	require.NoError(t, err)
+	require.NotEmpty(t, runID)
+
+	require.Eventually(t, func() bool {
+		run, err := store.GetRun(ctx, runID)
+		return err == nil && run.Status == TransitionFailed
+	}, time.Second*5, time.Millisecond*10)
+
+	run, err := store.GetRun(ctx, runID)
+	require.NoError(t, err)
+	assert.Equal(t, "flow_1", run.FlowID)
+	assert.Equal(t, "2", run.CurrentTask)
+
+	history, err := store.GetHistory(ctx, runID)
+	require.NoError(t, err)
+
+	type step struct {
+		taskID         string
+		transitionType TransitionType
+		failCount      int
+	}
+
+	steps := make([]step, 0, len(history))
+	for _, transition := range history {
+		assert.Equal(t, runID, transition.RunID)
+		steps = append(steps, step{transition.TaskID, transition.Type, transition.FailCount})
+	}
+
+	assert.Equal(t, []step{
+		{"head", TransitionStarted, 0},
+		{"head", TransitionSucceeded, 0},
+		{"2", TransitionStarted, 0},
+		{"2", TransitionRetried, 1},
+		{"2", TransitionStarted, 1},
+		{"2", TransitionFailed, 2},
+	}, steps)
+	assert.Equal(t, errTest.Error(), history[5].Error)
+}
@@ -0,0 +1,88 @@
+package taskmanager
+
+// Compensation is a succeeded step of the flow with the task rolling it back.
+//
+// The steps having a compensator are collected along the chain of the flow.
+// When a task fails for good and the flow has nowhere to go from it, the pool runs
+// the compensators of the collected steps one by one in reverse order, each gets the
+// result of its step as the previous result. A failed task with the fail successors
+// passes the steps to them instead, so a fallback may still finish the flow.
+// Parallel branches collect their own steps, a join continues with the steps of all its arrived parents.
+type Compensation struct {
+	StepID      string      `json:"step_id"`
+	Compensator TaskSetting `json:"compensator"`
+	Result      interface{} `json:"result,omitempty"`
+}
+
+// compensate returns the steps passed to the successors of the task and the compensator to run next.
+func compensate(meta TaskMeta, hasSuccessors bool, result interface{}, err error) ([]Compensation, *TaskMeta) {
+	switch {
+	case meta.Compensates != "":
+		// the compensator continues the rollback even if it failed itself
+		return nil, newCompensatorMeta(meta.FlowID, meta.Compensations)
+	case err == nil || hasSuccessors:
+		return passCompensations(meta, result, err), nil
+	default:
+		return nil, newCompensatorMeta(meta.FlowID, meta.Compensations)
+	}
+}
+
+// passCompensations returns the steps the task passes to its successors and joins.
+func passCompensations(meta TaskMeta, result interface{}, err error) []Compensation {
+	switch {
+	case meta.Compensates != "":
+		return nil
+	case err == nil && meta.Compensator != nil:
+		compensations := make([]Compensation, 0, len(meta.Compensations)+1)
+		compensations = append(compensations, meta.Compensations...)
+
+		return append(compensations, Compensation{
+			StepID:      meta.TaskID,
+			Compensator: *meta.Compensator,
+			Result:      result,
+		})
+	default:
+		return meta.Compensations
+	}
+}
+
+// mergeCompensations merges the steps of the arrived parents in the parents order.
+// The steps before the fork are shared by the branches, they are kept once in their place.
+func mergeCompensations(parents []string, arrivals map[string]JoinResult) []Compensation {
+	var compensations []Compensation
+
+	steps := make(map[string]struct{})
+	for _, parentID := range parents {
+		for _, compensation := range arrivals[parentID].Compensations {
+			if _, ok := steps[compensation.StepID]; ok {
+				continue
+			}
+
+			steps[compensation.StepID] = struct{}{}
+			compensations = append(compensations, compensation)
+		}
+	}
+
+	return compensations
+}
+
+func newCompensatorMeta(flowID string, compensations []Compensation) *TaskMeta {
+	if len(compensations) == 0 {
+		return nil
+	}
+
+	last := compensations[len(compensations)-1]
+
+	return &TaskMeta{
+		TaskID:         last.Compensator.TaskID,
+		FlowID:         flowID,
+		RunType:        last.Compensator.RunType,
+		RetryCount:     last.Compensator.RetryCount,
+		RetryPolicy:    last.Compensator.RetryPolicy,
+		Timeout:        last.Compensator.Timeout,
+		PreviousResult: last.Result,
+		PreviousTaskID: last.StepID,
+		Compensations:  compensations[:len(compensations)-1],
+		Compensates:    last.StepID,
+	}
+}
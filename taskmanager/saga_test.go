@@ -0,0 +1,152 @@
+package taskmanager
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/examples-go/logger"
+)
+
+type TestCompensatorCreator struct {
+	TestCreator
+}
+
+func (c *TestCompensatorCreator) Create(meta TaskMeta) (Task, error) {
+	if strings.HasPrefix(meta.TaskID, "c") {
+		return &TestTaskSuccess{TestTask{completeCount: &c.completeCount, mtx: &sync.Mutex{}}}, nil
+	}
+
+	return c.TestCreator.Create(meta)
+}
+
+func runSagaFlow(t *testing.T, flow *Flow) []ResultTaskData {
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, &TestCompensatorCreator{}, transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	results, err := pool.RunSync(ctx, TaskMeta{FlowID: "flow_1", TaskID: "head", RunType: SyncTask})
+	require.NoError(t, err)
+
+	return results
+}
+
+func TestPool_Compensation(t *testing.T) {
+	flow := NewFlow("flow_1")
+
+	flow.AddCondition("head", SuccessCondition, TaskSetting{
+		TaskID:      "1",
+		RunType:     SyncTask,
+		Compensator: &TaskSetting{TaskID: "c1", RunType: SyncTask},
+	})
+	flow.AddCondition("1", SuccessCondition, TaskSetting{
+		TaskID:      "3",
+		RunType:     SyncTask,
+		Compensator: &TaskSetting{TaskID: "c3", RunType: SyncTask},
+	})
+	flow.AddCondition("3", SuccessCondition, TaskSetting{
+		TaskID:      "4",
+		RunType:     SyncTask,
+		RetryCount:  2,
+		Compensator: &TaskSetting{TaskID: "c4", RunType: SyncTask},
+	})
+
+	results := runSagaFlow(t, flow)
+
+	taskIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		taskIDs = append(taskIDs, result.Meta.TaskID)
+	}
+
+	// the failed step is not compensated, the succeeded ones are rolled back in reverse order
+	assert.Equal(t, []string{"head", "1", "3", "4", "4", "c3", "c1"}, taskIDs)
+
+	assert.Equal(t, "3", results[5].Meta.Compensates)
+	assert.Equal(t, []string{"head", "1", "3"}, results[5].Meta.PreviousResult)
+	assert.Equal(t, "1", results[6].Meta.Compensates)
+	assert.Equal(t, []string{"head", "1"}, results[6].Meta.PreviousResult)
+	assert.Empty(t, results[6].Meta.Compensations)
+}
+
+func TestPool_CompensationFallback(t *testing.T) {
+	flow := NewFlow("flow_1")
+
+	flow.AddCondition("head", SuccessCondition, TaskSetting{
+		TaskID:      "1",
+		RunType:     SyncTask,
+		Compensator: &TaskSetting{TaskID: "c1", RunType: SyncTask},
+	})
+	flow.AddCondition("1", SuccessCondition, TaskSetting{TaskID: "2", RunType: SyncTask})
+	flow.AddCondition("2", FailCondition, TaskSetting{TaskID: "3", RunType: SyncTask})
+
+	results := runSagaFlow(t, flow)
+
+	taskIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		taskIDs = append(taskIDs, result.Meta.TaskID)
+	}
+
+	// the fallback finishes the flow, nothing to roll back
+	assert.Equal(t, []string{"head", "1", "2", "3"}, taskIDs)
+	require.Len(t, results[3].Meta.Compensations, 1)
+	assert.Equal(t, "1", results[3].Meta.Compensations[0].StepID)
+}
+
+func TestPool_CompensationJoin(t *testing.T) {
+	flow := NewFlow("flow_1")
+
+	flow.AddCondition("head", SuccessCondition, TaskSetting{
+		TaskID:      "1",
+		RunType:     SyncTask,
+		Compensator: &TaskSetting{TaskID: "c1", RunType: SyncTask},
+	})
+	flow.AddCondition("1", SuccessCondition,
+		TaskSetting{
+			TaskID:      "3",
+			RunType:     SyncTask,
+			Compensator: &TaskSetting{TaskID: "c3", RunType: SyncTask},
+		},
+		TaskSetting{
+			TaskID:      "cx",
+			RunType:     SyncTask,
+			Compensator: &TaskSetting{TaskID: "c5", RunType: SyncTask},
+		},
+	)
+	flow.AddJoin(Join{
+		Parents:   []string{"3", "cx"},
+		Condition: SuccessCondition,
+		Task:      TaskSetting{TaskID: "4", RunType: SyncTask},
+	})
+
+	results := runSagaFlow(t, flow)
+
+	taskIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		taskIDs = append(taskIDs, result.Meta.TaskID)
+	}
+
+	// the join rolls back the steps of both branches, the shared step is compensated once
+	require.Len(t, taskIDs, 8)
+	assert.Equal(t, []string{"4", "c5", "c3", "c1"}, taskIDs[4:])
+
+	stepIDs := make([]string, 0, len(results[4].Meta.Compensations))
+	for _, compensation := range results[4].Meta.Compensations {
+		stepIDs = append(stepIDs, compensation.StepID)
+	}
+
+	assert.Equal(t, []string{"1", "3", "cx"}, stepIDs)
+}
@@ -2,6 +2,12 @@ package taskmanager
 
 import (
 	"context"
+	"time"
+
+	"github.com/underbek/examples-go/logger"
+	"github.com/underbek/examples-go/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TaskRunType string
@@ -12,23 +18,122 @@ const (
 )
 
 type TaskTrace struct {
-	TraceID [16]byte `json:"trace_id"`
-	SpanID  [8]byte  `json:"span_id"`
+	TraceID [16]byte          `json:"trace_id"`
+	SpanID  [8]byte           `json:"span_id"`
+	Meta    map[string]string `json:"meta,omitempty"`
 }
 
 type TaskMeta struct {
 	TaskID     string      `json:"task_id"`
 	FlowID     string      `json:"flow_id"`
+	RunID      string      `json:"run_id,omitempty"`
 	RunType    TaskRunType `json:"run_type"`
 	RetryCount int         `json:"retry_count,omitempty"`
 
 	FailCount      int         `json:"fail_count,omitempty"`
 	PreviousResult interface{} `json:"previous_result,omitempty"`
+	// PreviousTaskID is the task produced PreviousResult, the result is decoded by its codec.
+	// It is empty for the heads and the joins
+	PreviousTaskID string `json:"previous_task_id,omitempty"`
+
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+	// PublishedAt is the time the task is sent to the transport, it measures the queue lag
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// Timeout limits a single run of the task, zero means no limit
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// NotBefore postpones the task, transports deliver it not earlier than this time
+	NotBefore *time.Time `json:"not_before,omitempty"`
+
+	// Compensator rolls the task back when the flow fails for good after it
+	Compensator *TaskSetting `json:"compensator,omitempty"`
+	// Compensations are the succeeded steps of the flow to roll back, the latest last
+	Compensations []Compensation `json:"compensations,omitempty"`
+	// Compensates is the step rolled back by this task, empty for the regular tasks
+	Compensates string `json:"compensates,omitempty"`
 
 	Trace      *TaskTrace  `json:"trace,omitempty"`
 	Additional interface{} `json:"additional,omitempty"`
+
+	// Delivery is a transport specific handle of the received task used by Ack and NoAck.
+	// It is never serialized.
+	Delivery interface{} `json:"-"`
 }
 
 type Task interface {
 	Run(context.Context, TaskMeta) (interface{}, error)
 }
+
+// NewTaskTrace captures the span and the logger meta of the context to continue them in the next task.
+// It returns nil when there is nothing to continue.
+func NewTaskTrace(ctx context.Context) *TaskTrace {
+	spanCtx := tracing.GetSpanContextFromContext(ctx)
+	meta := logger.ParseCtxMeta(ctx)
+
+	if !spanCtx.IsValid() && len(meta) == 0 {
+		return nil
+	}
+
+	return &TaskTrace{
+		TraceID: spanCtx.TraceID(),
+		SpanID:  spanCtx.SpanID(),
+		Meta:    meta,
+	}
+}
+
+// PutTaskTraceIntoContext restores the span and the logger meta captured by NewTaskTrace.
+func PutTaskTraceIntoContext(ctx context.Context, taskTrace *TaskTrace) context.Context {
+	if taskTrace == nil {
+		return ctx
+	}
+
+	if trace.TraceID(taskTrace.TraceID).IsValid() {
+		ctx = tracing.PutTraceInfoIntoContext(ctx, taskTrace.TraceID, taskTrace.SpanID)
+	}
+
+	if len(taskTrace.Meta) != 0 {
+		ctx = logger.AddCtxMetaValues(ctx, taskTrace.Meta)
+	}
+
+	return ctx
+}
+
+// startTaskSpan starts the span named after the task.
+// The sync task continues the span of the producer as its child.
+// The async task starts a new trace linked to the span of the producer instead of continuing it.
+func startTaskSpan(ctx context.Context, meta TaskMeta) (context.Context, trace.Span) { //nolint:ireturn
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(
+			attribute.String("task_manager.flow_id", meta.FlowID),
+			attribute.String("task_manager.task_id", meta.TaskID),
+			attribute.String("task_manager.run_id", meta.RunID),
+			attribute.Int("task_manager.fail_count", meta.FailCount),
+		),
+	}
+
+	kind := trace.SpanKindInternal
+
+	if meta.RunType != AsyncTask {
+		ctx = PutTaskTraceIntoContext(ctx, meta.Trace)
+
+		return tracing.StartCustomSpan(ctx, kind, "task_manager", meta.TaskID, opts...)
+	}
+
+	if meta.Trace != nil {
+		if len(meta.Trace.Meta) != 0 {
+			ctx = logger.AddCtxMetaValues(ctx, meta.Trace.Meta)
+		}
+
+		producer := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: meta.Trace.TraceID,
+			SpanID:  meta.Trace.SpanID,
+			Remote:  true,
+		})
+
+		if producer.IsValid() {
+			kind = trace.SpanKindConsumer
+			opts = append(opts, trace.WithNewRoot(), trace.WithLinks(trace.Link{SpanContext: producer}))
+		}
+	}
+
+	return tracing.StartCustomSpan(ctx, kind, "task_manager", meta.TaskID, opts...)
+}
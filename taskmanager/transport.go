@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 )
 
 var ErrChannelClosed = errors.New("channel closed")
@@ -39,6 +40,18 @@ func (t *ChannelTransport) Publish(_ context.Context, tasks ...TaskMeta) error {
 	}
 
 	for _, task := range tasks {
+		if task.NotBefore != nil {
+			if delay := time.Until(*task.NotBefore); delay > 0 {
+				// the channel can't hold postponed tasks, so they are published when due
+				task := task
+				time.AfterFunc(delay, func() {
+					_ = t.Publish(context.Background(), task)
+				})
+
+				continue
+			}
+		}
+
 		t.tasks <- task
 	}
 
@@ -0,0 +1,136 @@
+package taskmanager
+
+import (
+	"context"
+
+	ctxerrors "github.com/underbek/examples-go/errors"
+)
+
+// TypedTask is a task getting the previous result decoded into In.
+type TypedTask[In, Out any] interface {
+	Run(ctx context.Context, meta TaskMeta, in In) (Out, error)
+}
+
+// TypedTaskFunc is a function implementing TypedTask.
+type TypedTaskFunc[In, Out any] func(ctx context.Context, meta TaskMeta, in In) (Out, error)
+
+func (f TypedTaskFunc[In, Out]) Run(ctx context.Context, meta TaskMeta, in In) (Out, error) {
+	return f(ctx, meta, in)
+}
+
+// NewTask adapts the typed task to Task.
+// The previous result is decoded by the codec, a decode error fails the task.
+func NewTask[In, Out any](task TypedTask[In, Out], codec Codec) Task {
+	return &typedTask[In, Out]{
+		task:  task,
+		codec: codec,
+		decoder: func(TaskMeta) Codec {
+			return codec
+		},
+	}
+}
+
+type typedTask[In, Out any] struct {
+	task  TypedTask[In, Out]
+	codec Codec
+	// decoder returns the codec of the previous result
+	decoder func(TaskMeta) Codec
+}
+
+func (t *typedTask[In, Out]) Run(ctx context.Context, meta TaskMeta) (interface{}, error) {
+	var in In
+
+	switch previous := meta.PreviousResult.(type) {
+	case nil:
+	case In:
+		// not serialized by the transport
+		in = previous
+	default:
+		if err := t.decoder(meta).Decode(previous, &in); err != nil {
+			return nil, ctxerrors.Wrapf(err, ctxerrors.TypeInvalidRequest, "failed to decode input of task %s", meta.TaskID)
+		}
+	}
+
+	out, err := t.task.Run(ctx, meta, in)
+	if err != nil {
+		return out, err
+	}
+
+	return t.codec.Encode(out)
+}
+
+// TaskRegistry is a TaskCreator of the tasks registered by id with their codecs.
+type TaskRegistry struct {
+	creators map[string]func(TaskMeta) (Task, error)
+	codecs   map[string]Codec
+}
+
+var _ TaskCreator = (*TaskRegistry)(nil)
+
+func NewTaskRegistry() *TaskRegistry {
+	return &TaskRegistry{
+		creators: make(map[string]func(TaskMeta) (Task, error)),
+		codecs:   make(map[string]Codec),
+	}
+}
+
+// Register adds the untyped task.
+func (r *TaskRegistry) Register(taskID string, create func(TaskMeta) (Task, error)) {
+	r.creators[taskID] = create
+}
+
+// RegisterCodec sets the codec of the task, JSONCodec by default.
+func (r *TaskRegistry) RegisterCodec(taskID string, codec Codec) {
+	r.codecs[taskID] = codec
+}
+
+// Codec returns the codec of the task.
+func (r *TaskRegistry) Codec(taskID string) Codec {
+	codec, ok := r.codecs[taskID]
+	if !ok {
+		return JSONCodec{}
+	}
+
+	return codec
+}
+
+func (r *TaskRegistry) Create(meta TaskMeta) (Task, error) {
+	create, ok := r.creators[meta.TaskID]
+	if !ok {
+		return nil, ctxerrors.Errorf(ctxerrors.TypeNotFound, "task with id %s is not registered", meta.TaskID)
+	}
+
+	return create(meta)
+}
+
+// RegisterTypedTask adds the typed task encoding its result with the codec of the task id.
+// The input is decoded with the codec of the task produced it, TaskMeta.PreviousTaskID,
+// the codec of the task id decodes the input of the heads and the joins.
+func RegisterTypedTask[In, Out any](
+	registry *TaskRegistry,
+	taskID string,
+	create func(TaskMeta) (TypedTask[In, Out], error),
+) {
+	registry.Register(taskID, func(meta TaskMeta) (Task, error) {
+		task, err := create(meta)
+		if err != nil {
+			return nil, err
+		}
+
+		return &typedTask[In, Out]{
+			task:    task,
+			codec:   registry.Codec(taskID),
+			decoder: registry.decoder(taskID),
+		}, nil
+	})
+}
+
+func (r *TaskRegistry) decoder(taskID string) func(TaskMeta) Codec {
+	return func(meta TaskMeta) Codec {
+		if meta.PreviousTaskID != "" {
+			return r.Codec(meta.PreviousTaskID)
+		}
+
+		return r.Codec(taskID)
+	}
+}
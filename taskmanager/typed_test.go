@@ -0,0 +1,239 @@
+package taskmanager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctxerrors "github.com/underbek/examples-go/errors"
+	"github.com/underbek/examples-go/logger"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type testOrder struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+type testReceipt struct {
+	OrderID string `json:"order_id"`
+	Total   int    `json:"total"`
+}
+
+// transportRoundTrip serializes the meta the way the transports do
+func transportRoundTrip(t *testing.T, meta TaskMeta) TaskMeta {
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	var result TaskMeta
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	return result
+}
+
+func newTestRegistry() *TaskRegistry {
+	registry := NewTaskRegistry()
+
+	RegisterTypedTask[testOrder, testReceipt](registry, "pay", func(TaskMeta) (TypedTask[testOrder, testReceipt], error) {
+		return TypedTaskFunc[testOrder, testReceipt](func(_ context.Context, _ TaskMeta, in testOrder) (testReceipt, error) {
+			return testReceipt{OrderID: in.ID, Total: in.Amount * 2}, nil
+		}), nil
+	})
+
+	RegisterTypedTask[testReceipt, int](registry, "total", func(TaskMeta) (TypedTask[testReceipt, int], error) {
+		return TypedTaskFunc[testReceipt, int](func(_ context.Context, _ TaskMeta, in testReceipt) (int, error) {
+			return in.Total, nil
+		}), nil
+	})
+
+	RegisterTypedTask[int, int](registry, "count", func(TaskMeta) (TypedTask[int, int], error) {
+		return TypedTaskFunc[int, int](func(_ context.Context, _ TaskMeta, in int) (int, error) {
+			return in + 1, nil
+		}), nil
+	})
+
+	RegisterTypedTask[*wrapperspb.StringValue, *wrapperspb.StringValue](
+		registry,
+		"echo",
+		func(TaskMeta) (TypedTask[*wrapperspb.StringValue, *wrapperspb.StringValue], error) {
+			return TypedTaskFunc[*wrapperspb.StringValue, *wrapperspb.StringValue](
+				func(_ context.Context, _ TaskMeta, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+					return wrapperspb.String("echo " + in.GetValue()), nil
+				},
+			), nil
+		},
+	)
+	registry.RegisterCodec("echo", ProtoCodec{})
+
+	// the proto input of the task with the default codec
+	RegisterTypedTask[*wrapperspb.StringValue, int](
+		registry,
+		"length",
+		func(TaskMeta) (TypedTask[*wrapperspb.StringValue, int], error) {
+			return TypedTaskFunc[*wrapperspb.StringValue, int](
+				func(_ context.Context, _ TaskMeta, in *wrapperspb.StringValue) (int, error) {
+					return len(in.GetValue()), nil
+				},
+			), nil
+		},
+	)
+
+	return registry
+}
+
+func TestTypedTask(t *testing.T) {
+	ctx := context.Background()
+	registry := newTestRegistry()
+
+	task, err := registry.Create(TaskMeta{TaskID: "pay"})
+	require.NoError(t, err)
+
+	// the value is passed as is without a serializing transport
+	result, err := task.Run(ctx, TaskMeta{TaskID: "pay", PreviousResult: testOrder{ID: "1", Amount: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, testReceipt{OrderID: "1", Total: 20}, result)
+
+	meta := transportRoundTrip(t, TaskMeta{TaskID: "pay", PreviousResult: testOrder{ID: "2", Amount: 5}})
+	require.IsType(t, map[string]interface{}{}, meta.PreviousResult)
+
+	result, err = task.Run(ctx, meta)
+	require.NoError(t, err)
+	assert.Equal(t, testReceipt{OrderID: "2", Total: 10}, result)
+
+	_, err = task.Run(ctx, TaskMeta{TaskID: "pay", PreviousResult: map[string]interface{}{"amount": "ten"}})
+	assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(err))
+
+	_, err = registry.Create(TaskMeta{TaskID: "unknown"})
+	assert.Equal(t, ctxerrors.TypeNotFound, ctxerrors.ErrorType(err))
+}
+
+func TestTypedTask_Proto(t *testing.T) {
+	ctx := context.Background()
+
+	task, err := newTestRegistry().Create(TaskMeta{TaskID: "echo"})
+	require.NoError(t, err)
+
+	result, err := task.Run(ctx, TaskMeta{TaskID: "echo", PreviousResult: wrapperspb.String("first")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"echo first"`, string(result.(json.RawMessage)))
+
+	// the encoded result survives the transport and is decoded by the next task
+	meta := transportRoundTrip(t, TaskMeta{TaskID: "echo", PreviousResult: result})
+
+	result, err = task.Run(ctx, meta)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"echo echo first"`, string(result.(json.RawMessage)))
+}
+
+func TestTypedTask_MixedCodecs(t *testing.T) {
+	ctx := context.Background()
+	registry := newTestRegistry()
+
+	echo, err := registry.Create(TaskMeta{TaskID: "echo"})
+	require.NoError(t, err)
+
+	result, err := echo.Run(ctx, TaskMeta{TaskID: "echo", PreviousResult: wrapperspb.String("first")})
+	require.NoError(t, err)
+
+	length, err := registry.Create(TaskMeta{TaskID: "length"})
+	require.NoError(t, err)
+
+	// the protojson result of the ProtoCodec producer is decoded by its codec, not by the JSONCodec of the consumer
+	meta := transportRoundTrip(t, TaskMeta{TaskID: "length", PreviousTaskID: "echo", PreviousResult: result})
+
+	count, err := length.Run(ctx, meta)
+	require.NoError(t, err)
+	assert.Equal(t, len("echo first"), count)
+
+	// the consumer codec can't decode the protojson string into the message
+	meta.PreviousTaskID = ""
+
+	_, err = length.Run(ctx, meta)
+	assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(err))
+
+	// the plain input is decoded from the protojson through its JSON representation
+	var value string
+	require.NoError(t, ProtoCodec{}.Decode(result, &value))
+	assert.Equal(t, "echo first", value)
+}
+
+func TestPool_RunAsyncMixedCodecs(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition("echo", SuccessCondition, TaskSetting{TaskID: "length", RunType: AsyncTask})
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, newTestRegistry(), transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	next, _, err := pool.runTask(ctx, TaskMeta{
+		FlowID:         "flow_1",
+		TaskID:         "echo",
+		RunType:        AsyncTask,
+		PreviousResult: wrapperspb.String("first"),
+	})
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+
+	// the next task is serialized like the transports do
+	meta := transportRoundTrip(t, next[0])
+	assert.Equal(t, "echo", meta.PreviousTaskID)
+
+	_, data, err := pool.runTask(ctx, meta)
+	require.NoError(t, err)
+	require.NoError(t, data.Err)
+	assert.Equal(t, len("echo first"), data.Result)
+}
+
+func TestPool_RunSyncTypedTask(t *testing.T) {
+	flow := NewFlow("flow_1")
+	flow.AddCondition(
+		"pay",
+		SuccessCondition,
+		TaskSetting{TaskID: "total", RunType: SyncTask},
+		TaskSetting{TaskID: "count", RunType: SyncTask},
+	)
+
+	manager := NewFlowManager()
+	manager.AddFlow(flow)
+
+	transport := NewChannelTransport(10)
+	defer transport.Close()
+
+	lg, err := logger.New(true)
+	require.NoError(t, err)
+
+	pool := NewPool(lg, newTestRegistry(), transport, transport, manager)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	results, err := pool.RunSync(ctx, TaskMeta{
+		FlowID:         "flow_1",
+		TaskID:         "pay",
+		RunType:        SyncTask,
+		PreviousResult: map[string]interface{}{"id": "1", "amount": 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, testReceipt{OrderID: "1", Total: 20}, results[0].Result)
+	assert.Equal(t, 20, results[1].Result)
+
+	// the receipt is not a number, the decode error fails the task
+	assert.Equal(t, "count", results[2].Meta.TaskID)
+	assert.Equal(t, FailCondition, results[2].Condition)
+	assert.Equal(t, ctxerrors.TypeInvalidRequest, ctxerrors.ErrorType(results[2].Err))
+}